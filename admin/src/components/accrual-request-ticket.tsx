@@ -488,8 +488,8 @@ export function AccrualRequestTicket({ request }: AccrualRequestTicketProps) {
 
               <Separator className="my-4" />
 
-              {/* Action Buttons - Only show for pending/inprogress requests */}
-              {(request.status === "pending" || request.status === "inprogress") && (
+              {/* Action Buttons - Only show for requests still under review */}
+              {(request.status === "submitted" || request.status === "under_review") && (
                 <div className="flex items-center justify-between">
                   <div className="text-sm text-gray-500">
                     <p>{t('requestTicket.decisionRequired')}</p>
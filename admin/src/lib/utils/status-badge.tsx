@@ -2,9 +2,9 @@ import { Badge } from "@/components/ui/badge";
 
 export const getStatusBadge = (status: string, t: any) => {
   switch (status) {
-    case "pending":
+    case "submitted":
       return <Badge variant="outline" className="bg-yellow-100 text-yellow-800 border-yellow-300">{t('home.pending')}</Badge>;
-    case "inprogress":
+    case "under_review":
       return <Badge variant="outline" className="bg-blue-100 text-blue-800 border-blue-300">{t('home.inProgress')}</Badge>;
     case "approved":
       return <Badge variant="outline" className="bg-green-100 text-green-800 border-green-300">{t('home.approved')}</Badge>;
@@ -101,7 +101,8 @@ export default function HomePage() {
                 </SelectTrigger>
                 <SelectContent>
                   <SelectItem value="all">{t('common.all')}</SelectItem>
-                  <SelectItem value="inprogress">{t('home.inProgress')}</SelectItem>
+                  <SelectItem value="submitted">{t('home.pending')}</SelectItem>
+                  <SelectItem value="under_review">{t('home.inProgress')}</SelectItem>
                   <SelectItem value="approved">{t('home.approved')}</SelectItem>
                   <SelectItem value="rejected">{t('home.rejected')}</SelectItem>
                 </SelectContent>
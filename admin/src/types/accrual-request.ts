@@ -15,7 +15,7 @@ export interface Customer {
 export interface AccrualRequest {
   id: string;
   customer_id: string;
-  status: 'pending' | 'inprogress' | 'approved' | 'rejected';
+  status: 'submitted' | 'under_review' | 'needs_info' | 'approved' | 'rejected' | 'cancelled' | 'reversed';
   ticket_id: string;
   pnr: string;
   carrier: string;
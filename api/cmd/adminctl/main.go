@@ -0,0 +1,218 @@
+// adminctl runs back office operations against the AegisMiles database.
+//
+//	adminctl reprice -carrier VN -departure-from 2025-06-01 -reason "..." -actor <user id> [-include-approved] [-sessionm] [-apply]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/viebiz/lit/env"
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/monitoring"
+	"github.com/viebiz/lit/monitoring/instrumentpg"
+	"github.com/viebiz/lit/postgres"
+	driverpg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/config"
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/gateway/sessionm"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/campaign"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/mileage"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/risk"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+
+	var err error
+	switch os.Args[1] {
+	case "reprice":
+		err = reprice(ctx, os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Printf("adminctl %s failed: %+v", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: adminctl <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  reprice   price pending accrual requests again, dry run unless -apply is given")
+}
+
+func reprice(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("reprice", flag.ExitOnError)
+	requestIDs := fs.String("requests", "", "comma separated accrual request IDs")
+	chartID := fs.String("chart", "", "accrual rate chart ID the segments were priced with")
+	carrier := fs.String("carrier", "", "marketing carrier")
+	departureFrom := fs.String("departure-from", "", "first departure date, YYYY-MM-DD")
+	departureTo := fs.String("departure-to", "", "last departure date, YYYY-MM-DD")
+	includeApproved := fs.Bool("include-approved", false, "also issue corrections for approved segments")
+	useSessionM := fs.Bool("sessionm", false, "send corrections to SessionM instead of the local balance")
+	apply := fs.Bool("apply", false, "apply the new amounts, otherwise only print the diff")
+	reason := fs.String("reason", "", "why the requests are re-priced, required with -apply")
+	actor := fs.String("actor", "", "user ID recorded in the audit trail, required with -apply")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *apply && *actor == "" {
+		return errors.New("-actor is required with -apply")
+	}
+
+	input := dto.RepriceInput{
+		AccrualRateChartID: *chartID,
+		Carrier:            *carrier,
+		IncludeApproved:    *includeApproved,
+		DryRun:             !*apply,
+		Reason:             *reason,
+	}
+	if *requestIDs != "" {
+		input.RequestIDs = strings.Split(*requestIDs, ",")
+	}
+
+	var err error
+	if input.DepartureFrom, err = parseDate(*departureFrom); err != nil {
+		return err
+	}
+	if input.DepartureTo, err = parseDate(*departureTo); err != nil {
+		return err
+	}
+
+	cfg, err := env.ReadAppConfig[config.Config]()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Accrual.Rounding.Validate(); err != nil {
+		return err
+	}
+
+	monitor, err := monitoring.New(monitoring.Config{
+		ServerName: cfg.ServerName,
+		SentryDSN:  cfg.SentryDSN,
+	})
+	if err != nil {
+		return err
+	}
+	ctx = monitoring.SetInContext(ctx, monitor)
+
+	if err := generator.Setup(); err != nil {
+		return err
+	}
+
+	db, err := connectDatabase(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	repo := repository.New(db)
+	campaignSvc := campaign.New(repo, cfg.Accrual.Rounding)
+
+	riskSvc := risk.New(cfg.Risk, repo)
+	mileageSvc := mileage.New(cfg.Accrual, repo, campaignSvc, riskSvc)
+	if *useSessionM {
+		sessionmGwy, err := sessionm.New(cfg.SessionM)
+		if err != nil {
+			return err
+		}
+		mileageSvc = mileage.NewV2(cfg.SessionM, cfg.Accrual, sessionmGwy, repo, campaignSvc, riskSvc)
+	}
+
+	// The service records who re-priced from the signed in user, as it does for the admin API
+	ctx = iam.SetUserProfileInContext(ctx, iam.NewUserProfile(*actor, []string{constants.UserRoleAdmin}, nil))
+
+	result, err := mileageSvc.RepriceAccrualRequests(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	printRepricing(result)
+	return nil
+}
+
+func printRepricing(result dto.RepricingResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "REQUEST\tSEGMENT\tROUTE\tSTATUS\tQUALIFYING\tBONUS\tTIER BONUS\tNOTE")
+	for _, item := range result.Items {
+		if item.Error != "" {
+			fmt.Fprintf(w, "%s\t\t\t\t\t\t\tskipped: %s\n", item.AccrualRequestID, item.Error)
+			continue
+		}
+
+		note := ""
+		if item.Correction {
+			note = "correction"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s-%s\t%s\t%d -> %d\t%d -> %d\t%d -> %d\t%s\n",
+			item.AccrualRequestID, item.SegmentID, item.FromCode, item.ToCode, item.SegmentStatus,
+			item.OldQualifyingMiles, item.NewQualifyingMiles,
+			item.OldBonusMiles, item.NewBonusMiles,
+			item.OldTierBonusMiles, item.NewTierBonusMiles,
+			note)
+	}
+	_ = w.Flush()
+
+	if result.DryRun {
+		fmt.Printf("\ndry run: %d segments would change across %d requests, re-run with -apply to save\n", result.Changed, result.Requests)
+		return
+	}
+	fmt.Printf("\nrun %s: re-priced %d segments across %d requests\n", result.RunID, result.Changed, result.Requests)
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", value, err)
+	}
+	return &date, nil
+}
+
+func connectDatabase(ctx context.Context, cfg config.Config) (*gorm.DB, error) {
+	pool, err := postgres.NewPool(ctx, cfg.Database.URL, cfg.Database.MaxOpenConns, cfg.Database.MaxIdleConns, postgres.AttemptPingUponStartup())
+	if err != nil {
+		return nil, err
+	}
+
+	return gorm.Open(driverpg.New(driverpg.Config{
+		Conn: instrumentpg.WithInstrumentation(pool),
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Warn),
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/viebiz/lit"
 	"github.com/viebiz/lit/cors"
@@ -47,8 +48,13 @@ import (
 	"github.com/erwin-lovecraft/aegismiles/internal/gateway/sessionm"
 	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
 	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/accrualrate"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/airport"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/bookingclass"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/campaign"
 	"github.com/erwin-lovecraft/aegismiles/internal/services/customer"
 	"github.com/erwin-lovecraft/aegismiles/internal/services/mileage"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/risk"
 	"github.com/viebiz/lit/httpclient"
 )
 
@@ -106,6 +112,10 @@ func run(ctx context.Context) error {
 		return err
 	}
 
+	if err := cfg.Accrual.Rounding.Validate(); err != nil {
+		return err
+	}
+
 	// Initialize monitoring for logging and tracing
 	monitor, err := monitoring.New(monitoring.Config{
 		ServerName: cfg.ServerName,
@@ -141,13 +151,18 @@ func run(ctx context.Context) error {
 	}
 
 	repo := repository.New(db)
-	mileageSvc := mileage.New(repo)
+	campaignSvc := campaign.New(repo, cfg.Accrual.Rounding)
+	riskSvc := risk.New(cfg.Risk, repo)
+	mileageSvc := mileage.New(cfg.Accrual, repo, campaignSvc, riskSvc)
 	customerSvc := customer.New(repo, authGwy)
-	v1Ctrl := v1.New(customerSvc, mileageSvc)
+	accrualRateSvc := accrualrate.New(repo)
+	airportSvc := airport.New(repo)
+	bookingClassSvc := bookingclass.New(repo)
+	v1Ctrl := v1.New(customerSvc, mileageSvc, accrualRateSvc, campaignSvc, airportSvc, bookingClassSvc)
 
 	// Initialize v2 services
-	customerV2Svc := customer.NewV2(cfg.SessionM, repo, authGwy, sessionmGwy)
-	mileageV2Svc := mileage.NewV2(cfg.SessionM, sessionmGwy, repo)
+	customerV2Svc := customer.NewV2(cfg.SessionM, cfg.Accrual.Rounding, repo, authGwy, sessionmGwy)
+	mileageV2Svc := mileage.NewV2(cfg.SessionM, cfg.Accrual, sessionmGwy, repo, campaignSvc, riskSvc)
 	v2Ctrl := v2.New(customerV2Svc, mileageV2Svc)
 
 	// Initialize the server with the handler
@@ -160,8 +175,35 @@ func routes(ctx context.Context, cfg config.Config, v1Ctrl v1.Controller, v2Ctrl
 	r := lit.NewRouter(ctx)
 	r.Use(cors.Middleware(configCORS(cfg.Cors)))
 
+	// Public routes, the claim form looks up reference data before sign in
+	publicRoute := r.Route("/api/v1/airports",
+		httpmw.RequestIDMiddleware(),
+	)
+	publicRoute.Get("", v1Ctrl.SearchAirports)
+
+	bookingClassRoute := r.Route("/api/v1/booking-classes",
+		httpmw.RequestIDMiddleware(),
+	)
+	bookingClassRoute.Get("", v1Ctrl.GetBookingClasses)
+
+	// Public quotes for the landing page, rate limited per client
+	rateLimit := cfg.PublicAPI.RateLimitPerMinute
+	if rateLimit <= 0 {
+		rateLimit = 30
+	}
+	publicQuoteRoute := r.Route("/api/v1/public/accrual-quotes",
+		httpmw.RequestIDMiddleware(),
+		middleware.RateLimit(rateLimit, time.Minute),
+	)
+	publicQuoteRoute.Post("", v1Ctrl.QuotePublicAccrual)
+
+	// Messages such as rejection reasons follow the caller's language, the bundles are in resources/i18n
+	localization := httpmw.LocalizationMiddleware(ctx, httpmw.Config{HeaderKey: middleware.HeaderLanguage})
+
 	v1Route := r.Route("/api/v1",
 		httpmw.RequestIDMiddleware(),
+		middleware.Language(),
+		localization,
 		// Disable auth for testing
 		middleware.Authenticate(cfg),
 	)
@@ -176,14 +218,90 @@ func routes(ctx context.Context, cfg config.Config, v1Ctrl v1.Controller, v2Ctrl
 		// accrual.Use(middleware.HasRoles(constants.UserRoleMember))
 		accrual.Post("", v1Ctrl.SubmitAccrualRequest)
 		accrual.Get("", v1Ctrl.GetMyAccrualRequests)
+		accrual.Get(":id", v1Ctrl.GetMyAccrualRequest)
+		accrual.Get(":id/comments", v1Ctrl.GetMyComments)
+		accrual.Post(":id/comments", v1Ctrl.AddMyComment)
+		accrual.Patch(":id/cancel", v1Ctrl.CancelRequest)
+		accrual.Patch(":id/resubmit", v1Ctrl.ResubmitRequest)
+	})
+
+	// Accrual quote routes
+	v1Route.Group("/accrual-quotes", func(quote lit.Router) {
+		quote.Post("", v1Ctrl.QuoteAccrual)
 	})
 
 	// Admin accrual requests routes
 	v1Route.Group("/admin/accrual-requests", func(admin lit.Router) {
 		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
 		admin.Get("", v1Ctrl.GetAccrualRequests)
+		admin.Post("", v1Ctrl.SubmitAccrualRequestOnBehalf)
+		admin.Get("queue", v1Ctrl.GetReviewQueue)
+		admin.Get("sla-report", v1Ctrl.GetReviewSLAReport)
+		admin.Get("rejection-reasons", v1Ctrl.GetRejectionReasons)
+		admin.Get("rejection-report", v1Ctrl.GetRejectionReport)
+		admin.Get(":id", v1Ctrl.GetAccrualRequest)
+		admin.Get(":id/comments", v1Ctrl.GetComments)
+		admin.Post(":id/comments", v1Ctrl.AddComment)
+		admin.Patch(":id/lock", v1Ctrl.LockRequest)
+		admin.Patch(":id/unlock", v1Ctrl.UnlockRequest)
+		admin.Patch(":id/boarding-pass/verify", v1Ctrl.VerifyBoardingPass)
 		admin.Patch(":id/approve", v1Ctrl.ApproveRequest)
 		admin.Patch(":id/reject", v1Ctrl.RejectRequest)
+		admin.Patch(":id/needs-info", v1Ctrl.RequestInfo)
+		admin.Patch(":id/reverse", v1Ctrl.ReverseRequest)
+		admin.Patch(":id/deposits/retry", v1Ctrl.RetryDeposits)
+		admin.Patch(":id/segments/:segment_id/approve", v1Ctrl.ApproveSegment)
+		admin.Patch(":id/segments/:segment_id/reject", v1Ctrl.RejectSegment)
+	})
+
+	// Admin bulk review, answered per request so a failure does not undo the others
+	v1Route.Group("/admin/accrual-requests:action", func(admin lit.Router) {
+		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Post("", v1Ctrl.BatchReview)
+	})
+
+	// Admin auto approval policy, patching the kill switch stops auto approvals at once
+	v1Route.Group("/admin/auto-approval", func(admin lit.Router) {
+		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Get("policy", v1Ctrl.GetAutoApprovalPolicy)
+		admin.Put("policy", v1Ctrl.UpdateAutoApprovalPolicy)
+		admin.Patch("kill-switch", v1Ctrl.DisableAutoApproval)
+		admin.Get("report", v1Ctrl.GetAutoApprovalReport)
+	})
+
+	// Admin re-pricing routes, corrections go to the local balance
+	v1Route.Group("/admin/accrual-repricings", func(admin lit.Router) {
+		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Get("", v1Ctrl.GetRepricings)
+		admin.Post("", v1Ctrl.RepriceAccrualRequests)
+	})
+
+	// Admin accrual rate chart routes
+	v1Route.Group("/admin/accrual-rates", func(admin lit.Router) {
+		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Get("", v1Ctrl.GetAccrualRateCharts)
+		admin.Post("", v1Ctrl.CreateAccrualRateChart)
+		admin.Get(":id", v1Ctrl.GetAccrualRateChart)
+		admin.Put(":id", v1Ctrl.UpdateAccrualRateChart)
+		admin.Delete(":id", v1Ctrl.DeleteAccrualRateChart)
+	})
+
+	// Campaign routes
+	v1Route.Group("/campaigns", func(campaign lit.Router) {
+		campaign.Get("", v1Ctrl.GetRunningCampaigns)
+		campaign.Post(":id/opt-in", v1Ctrl.OptInCampaign)
+	})
+
+	// Admin campaign routes
+	v1Route.Group("/admin/campaigns", func(admin lit.Router) {
+		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Get("", v1Ctrl.GetCampaigns)
+		admin.Post("", v1Ctrl.CreateCampaign)
+		admin.Get(":id", v1Ctrl.GetCampaign)
+		admin.Put(":id", v1Ctrl.UpdateCampaign)
+		admin.Patch(":id/schedule", v1Ctrl.ScheduleCampaign)
+		admin.Patch(":id/pause", v1Ctrl.PauseCampaign)
+		admin.Get(":id/report", v1Ctrl.GetCampaignReport)
 	})
 
 	// Miles ledger routes
@@ -200,6 +318,8 @@ func routes(ctx context.Context, cfg config.Config, v1Ctrl v1.Controller, v2Ctrl
 	// v2 API routes
 	v2Route := r.Route("/api/v2",
 		httpmw.RequestIDMiddleware(),
+		middleware.Language(),
+		localization,
 		middleware.Authenticate(cfg),
 	)
 
@@ -213,13 +333,56 @@ func routes(ctx context.Context, cfg config.Config, v1Ctrl v1.Controller, v2Ctrl
 		// accrual.Use(middleware.HasRoles(constants.UserRoleMember))
 		accrual.Post("", v2Ctrl.SubmitAccrualRequest)
 		accrual.Get("", v2Ctrl.GetAccrualRequests)
+		accrual.Get(":id", v2Ctrl.GetMyAccrualRequest)
+		accrual.Get(":id/comments", v1Ctrl.GetMyComments)
+		accrual.Post(":id/comments", v1Ctrl.AddMyComment)
+		accrual.Patch(":id/cancel", v1Ctrl.CancelRequest)
+		accrual.Patch(":id/resubmit", v1Ctrl.ResubmitRequest)
 	})
 
 	// Admin accrual requests routes
 	v2Route.Group("/admin/accrual-requests", func(admin lit.Router) {
 		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Post("", v1Ctrl.SubmitAccrualRequestOnBehalf)
+		admin.Get("queue", v1Ctrl.GetReviewQueue)
+		admin.Get("sla-report", v1Ctrl.GetReviewSLAReport)
+		admin.Get("rejection-reasons", v1Ctrl.GetRejectionReasons)
+		admin.Get("rejection-report", v1Ctrl.GetRejectionReport)
+		admin.Get(":id", v2Ctrl.GetAccrualRequest)
+		admin.Get(":id/comments", v1Ctrl.GetComments)
+		admin.Post(":id/comments", v1Ctrl.AddComment)
+		admin.Patch(":id/lock", v1Ctrl.LockRequest)
+		admin.Patch(":id/unlock", v1Ctrl.UnlockRequest)
+		admin.Patch(":id/boarding-pass/verify", v2Ctrl.VerifyBoardingPass)
 		admin.Patch(":id/approve", v2Ctrl.ApproveRequest)
 		admin.Patch(":id/reject", v1Ctrl.RejectRequest)
+		admin.Patch(":id/needs-info", v1Ctrl.RequestInfo)
+		admin.Patch(":id/reverse", v2Ctrl.ReverseRequest)
+		admin.Patch(":id/deposits/retry", v2Ctrl.RetryDeposits)
+		admin.Patch(":id/segments/:segment_id/approve", v2Ctrl.ApproveSegment)
+		admin.Patch(":id/segments/:segment_id/reject", v1Ctrl.RejectSegment)
+	})
+
+	// Admin bulk review, answered per request so a failure does not undo the others
+	v2Route.Group("/admin/accrual-requests:action", func(admin lit.Router) {
+		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Post("", v2Ctrl.BatchReview)
+	})
+
+	// Admin auto approval policy, patching the kill switch stops auto approvals at once
+	v2Route.Group("/admin/auto-approval", func(admin lit.Router) {
+		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Get("policy", v1Ctrl.GetAutoApprovalPolicy)
+		admin.Put("policy", v1Ctrl.UpdateAutoApprovalPolicy)
+		admin.Patch("kill-switch", v1Ctrl.DisableAutoApproval)
+		admin.Get("report", v1Ctrl.GetAutoApprovalReport)
+	})
+
+	// Admin re-pricing routes, corrections go to SessionM
+	v2Route.Group("/admin/accrual-repricings", func(admin lit.Router) {
+		admin.Use(middleware.HasRoles(constants.UserRoleAdmin))
+		admin.Get("", v1Ctrl.GetRepricings)
+		admin.Post("", v2Ctrl.RepriceAccrualRequests)
 	})
 
 	// Miles ledger routes
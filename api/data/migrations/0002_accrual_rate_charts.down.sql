@@ -0,0 +1,3 @@
+ALTER TABLE accrual_requests DROP COLUMN IF EXISTS accrual_rate_chart_id;
+DROP TABLE IF EXISTS accrual_rates;
+DROP TABLE IF EXISTS accrual_rate_charts;
@@ -0,0 +1,66 @@
+-- Accrual rate charts, effective-dated by departure date
+CREATE TABLE accrual_rate_charts
+(
+    id         UUID PRIMARY KEY,
+    name       TEXT NOT NULL,
+    valid_from DATE NOT NULL,
+    valid_to   DATE NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+-- Accrual rates per booking class within a chart
+CREATE TABLE accrual_rates
+(
+    id              UUID PRIMARY KEY,
+    chart_id        UUID           NOT NULL REFERENCES accrual_rate_charts (id) ON DELETE CASCADE,
+    booking_class   TEXT           NOT NULL,
+    qualifying_rate NUMERIC(10, 2) NOT NULL,
+    bonus_tiers     JSONB          NOT NULL DEFAULT '{"miles": [], "values": []}',
+    created_at      TIMESTAMPTZ DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (chart_id, booking_class)
+);
+
+ALTER TABLE accrual_requests
+    ADD COLUMN accrual_rate_chart_id UUID NULL REFERENCES accrual_rate_charts (id);
+
+-- Baseline chart carried over from the previous hard-coded rates
+INSERT INTO accrual_rate_charts (id, name, valid_from, valid_to)
+VALUES ('00000000-0000-0000-0000-000000000001', 'Baseline', '2000-01-01', NULL);
+
+INSERT INTO accrual_rates (id, chart_id, booking_class, qualifying_rate, bonus_tiers)
+VALUES
+-- ---------------- Business ----------------
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'J', 2.00, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [2.5, 3, 4.6, 8.6, 12]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'C', 2.00, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [2.5, 3, 4.6, 8.6, 12]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'D', 1.50, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [2, 2.2, 3.5, 6.5, 9]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'I', 1.50, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [2, 2.2, 3.5, 6.5, 9]}'),
+-- ---------------- Premium Economy ----------------
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'W', 1.30, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1.8, 2, 3, 5.6, 8]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'Z', 1.20, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1.6, 1.8, 2.7, 5.2, 7.4]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'U', 1.20, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1.6, 1.8, 2.7, 5.2, 7.4]}'),
+-- ---------------- Economy (Flex) ----------------
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'Y', 1.10, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1.4, 1.5, 2.3, 4.3, 6]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'M', 1.10, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1.4, 1.5, 2.3, 4.3, 6]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'B', 1.10, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1.4, 1.5, 2.3, 4.3, 6]}'),
+-- ---------------- Economy (Classic) ----------------
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'S', 0.65, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1, 1.1, 1.5, 2.8, 4]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'H', 0.65, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1, 1.1, 1.5, 2.8, 4]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'K', 0.65, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1, 1.1, 1.5, 2.8, 4]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'L', 0.65, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [1, 1.1, 1.5, 2.8, 4]}'),
+-- ---------------- Economy (Lite) ----------------
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'Q', 0.25, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [0.5, 0.5, 0.5, 0.5, 0.5]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'N', 0.25, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [0.5, 0.5, 0.5, 0.5, 0.5]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'R', 0.25, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [0.5, 0.5, 0.5, 0.5, 0.5]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'T', 0.25, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [0.5, 0.5, 0.5, 0.5, 0.5]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'E', 0.25, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [0.5, 0.5, 0.5, 0.5, 0.5]}'),
+-- ---------------- Economy (Super Lite) ----------------
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'A', 0.10, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [0, 0, 0, 0, 0]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'P', 0.10, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [0, 0, 0, 0, 0]}'),
+(gen_random_uuid(), '00000000-0000-0000-0000-000000000001', 'G', 0.10, '{"miles": [1000, 2000, 3000, 5000, 9223372036854775807], "values": [0, 0, 0, 0, 0]}');
+
+-- Existing requests were priced with the baseline rates
+UPDATE accrual_requests
+SET accrual_rate_chart_id = '00000000-0000-0000-0000-000000000001';
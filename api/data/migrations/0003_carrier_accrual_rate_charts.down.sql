@@ -0,0 +1,5 @@
+ALTER TABLE accrual_requests DROP COLUMN IF EXISTS operating_carrier;
+DROP INDEX IF EXISTS idx_accrual_rate_charts_carrier;
+ALTER TABLE accrual_rate_charts
+    DROP COLUMN IF EXISTS operating_carrier,
+    DROP COLUMN IF EXISTS carrier;
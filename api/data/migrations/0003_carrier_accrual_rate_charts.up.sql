@@ -0,0 +1,47 @@
+-- Scope accrual rate charts per marketing / operating carrier
+ALTER TABLE accrual_rate_charts
+    ADD COLUMN carrier           TEXT NULL,
+    ADD COLUMN operating_carrier TEXT NULL;
+
+-- A chart takes the carrier of the requests it priced
+UPDATE accrual_rate_charts c
+SET carrier = r.carrier
+FROM (SELECT accrual_rate_chart_id, MIN(UPPER(carrier)) AS carrier
+      FROM accrual_requests
+      WHERE accrual_rate_chart_id IS NOT NULL
+        AND TRIM(carrier) <> ''
+      GROUP BY accrual_rate_chart_id
+      HAVING COUNT(DISTINCT UPPER(carrier)) = 1) r
+WHERE r.accrual_rate_chart_id = c.id;
+
+-- The baseline chart holds the rates that were hard coded for VN, it keeps them when nothing was priced with it yet
+UPDATE accrual_rate_charts
+SET carrier = 'VN'
+WHERE id = '00000000-0000-0000-0000-000000000001'
+  AND carrier IS NULL
+  AND NOT EXISTS (SELECT 1 FROM accrual_requests WHERE accrual_rate_chart_id = '00000000-0000-0000-0000-000000000001');
+
+-- A chart without requests, or priced for several carriers, needs its carrier set by hand before migrating
+DO
+$$
+    DECLARE
+        unknown TEXT;
+    BEGIN
+        SELECT STRING_AGG(id::TEXT || ' (' || name || ')', ', ')
+        INTO unknown
+        FROM accrual_rate_charts
+        WHERE carrier IS NULL;
+
+        IF unknown IS NOT NULL THEN
+            RAISE EXCEPTION 'accrual rate charts without a known carrier: %', unknown;
+        END IF;
+    END
+$$;
+
+ALTER TABLE accrual_rate_charts
+    ALTER COLUMN carrier SET NOT NULL;
+
+CREATE INDEX idx_accrual_rate_charts_carrier ON accrual_rate_charts (carrier, operating_carrier, valid_from);
+
+ALTER TABLE accrual_requests
+    ADD COLUMN operating_carrier TEXT NULL;
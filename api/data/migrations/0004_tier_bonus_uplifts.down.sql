@@ -0,0 +1,6 @@
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS tier_bonus_miles,
+    DROP COLUMN IF EXISTS tier_bonus_rate,
+    DROP COLUMN IF EXISTS member_tier;
+
+ALTER TABLE accrual_rate_charts DROP COLUMN IF EXISTS tier_bonus_uplifts;
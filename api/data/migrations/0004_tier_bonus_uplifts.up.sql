@@ -0,0 +1,8 @@
+-- Per-tier bonus uplift, effective with the rate chart
+ALTER TABLE accrual_rate_charts
+    ADD COLUMN tier_bonus_uplifts JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE accrual_requests
+    ADD COLUMN member_tier      TEXT           NOT NULL DEFAULT '',
+    ADD COLUMN tier_bonus_rate  NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tier_bonus_miles NUMERIC(10, 2) NOT NULL DEFAULT 0;
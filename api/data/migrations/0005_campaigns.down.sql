@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_miles_ledgers_campaign_id;
+
+ALTER TABLE miles_ledgers DROP COLUMN IF EXISTS campaign_id;
+
+DROP TABLE IF EXISTS campaign_opt_ins;
+
+DROP TABLE IF EXISTS campaigns;
@@ -0,0 +1,39 @@
+-- Promotion campaigns awarding bonus miles on top of the accrual
+CREATE TABLE campaigns
+(
+    id              UUID PRIMARY KEY,
+    name            TEXT           NOT NULL,
+    description     TEXT,
+    status          TEXT           NOT NULL DEFAULT 'draft',
+    starts_at       TIMESTAMPTZ    NOT NULL,
+    ends_at         TIMESTAMPTZ    NULL,
+    from_code       TEXT           NULL,
+    to_code         TEXT           NULL,
+    bidirectional   BOOLEAN        NOT NULL DEFAULT FALSE,
+    booking_classes JSONB          NOT NULL DEFAULT '[]',
+    member_tiers    JSONB          NOT NULL DEFAULT '[]',
+    departure_from  DATE           NULL,
+    departure_to    DATE           NULL,
+    requires_opt_in BOOLEAN        NOT NULL DEFAULT FALSE,
+    bonus_rate      NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    fixed_miles     NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (ends_at IS NULL OR ends_at >= starts_at),
+    CHECK (departure_to IS NULL OR departure_from IS NULL OR departure_to >= departure_from)
+);
+
+CREATE INDEX idx_campaigns_status_starts_at ON campaigns (status, starts_at);
+
+CREATE TABLE campaign_opt_ins
+(
+    campaign_id UUID NOT NULL REFERENCES campaigns (id),
+    customer_id UUID NOT NULL REFERENCES customers (id),
+    created_at  TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (campaign_id, customer_id)
+);
+
+ALTER TABLE miles_ledgers
+    ADD COLUMN campaign_id UUID NULL REFERENCES campaigns (id);
+
+CREATE INDEX idx_miles_ledgers_campaign_id ON miles_ledgers (campaign_id);
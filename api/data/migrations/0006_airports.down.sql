@@ -0,0 +1,3 @@
+ALTER TABLE accrual_requests DROP COLUMN IF EXISTS distance_source;
+
+DROP TABLE IF EXISTS airports;
@@ -0,0 +1,13 @@
+-- Airport reference data, used to compute great-circle distances for unpublished routes
+CREATE TABLE airports
+(
+    code       TEXT PRIMARY KEY,
+    name       TEXT             NOT NULL,
+    latitude   DOUBLE PRECISION NOT NULL,
+    longitude  DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+ALTER TABLE accrual_requests
+    ADD COLUMN distance_source TEXT NOT NULL DEFAULT 'published';
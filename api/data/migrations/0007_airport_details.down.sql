@@ -0,0 +1,4 @@
+ALTER TABLE airports
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS city;
@@ -0,0 +1,5 @@
+-- Airport details for the claim form autocomplete, reload data/seeds/airports.sql afterwards
+ALTER TABLE airports
+    ADD COLUMN city     TEXT NOT NULL DEFAULT '',
+    ADD COLUMN country  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE miles_ledgers DROP COLUMN IF EXISTS accrual_request_segment_id;
+
+DROP TABLE IF EXISTS accrual_request_segments;
@@ -0,0 +1,71 @@
+-- Segments of an itinerary claim, each priced and reviewed on its own
+CREATE TABLE accrual_request_segments
+(
+    id                      UUID PRIMARY KEY,
+    accrual_request_id      UUID           NOT NULL REFERENCES accrual_requests (id),
+    segment_no              INT            NOT NULL,
+    status                  TEXT           NOT NULL,
+    ticket_id               TEXT           NOT NULL,
+    coupon_number           INT            NOT NULL DEFAULT 0,
+    carrier                 TEXT           NOT NULL,
+    operating_carrier       TEXT           NULL,
+    booking_class           TEXT           NOT NULL,
+    from_code               TEXT           NOT NULL,
+    to_code                 TEXT           NOT NULL,
+    departure_date          TIMESTAMPTZ    NOT NULL,
+    distance_miles          INT            NOT NULL,
+    distance_source         TEXT           NOT NULL DEFAULT 'published',
+    accrual_rate_chart_id   UUID           NULL REFERENCES accrual_rate_charts (id),
+    qualifying_accrual_rate NUMERIC(10, 2) NOT NULL,
+    qualifying_miles        NUMERIC(10, 2) NOT NULL,
+    bonus_accrual_rate      NUMERIC(10, 2) NOT NULL,
+    bonus_miles             NUMERIC(10, 2) NOT NULL,
+    tier_bonus_rate         NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    tier_bonus_miles        NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    rejected_reason         TEXT           NULL,
+    created_at              TIMESTAMPTZ DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (accrual_request_id, segment_no)
+);
+
+-- Duplicate claims are looked up by ticket and coupon
+CREATE INDEX idx_accrual_request_segments_ticket_id ON accrual_request_segments (ticket_id, coupon_number);
+
+-- Every existing request becomes a single segment claim
+INSERT INTO accrual_request_segments (id, accrual_request_id, segment_no, status, ticket_id, carrier, operating_carrier,
+                                      booking_class, from_code, to_code, departure_date, distance_miles,
+                                      distance_source, accrual_rate_chart_id, qualifying_accrual_rate,
+                                      qualifying_miles, bonus_accrual_rate, bonus_miles, tier_bonus_rate,
+                                      tier_bonus_miles, rejected_reason, created_at, updated_at)
+SELECT gen_random_uuid(),
+       id,
+       1,
+       status,
+       ticket_id,
+       carrier,
+       operating_carrier,
+       booking_class,
+       from_code,
+       to_code,
+       departure_date,
+       distance_miles,
+       distance_source,
+       accrual_rate_chart_id,
+       qualifying_accrual_rate,
+       qualifying_miles,
+       bonus_accrual_rate,
+       bonus_miles,
+       tier_bonus_rate,
+       tier_bonus_miles,
+       rejected_reason,
+       created_at,
+       updated_at
+FROM accrual_requests;
+
+ALTER TABLE miles_ledgers
+    ADD COLUMN accrual_request_segment_id UUID NULL REFERENCES accrual_request_segments (id);
+
+UPDATE miles_ledgers
+SET accrual_request_segment_id = accrual_request_segments.id
+FROM accrual_request_segments
+WHERE accrual_request_segments.accrual_request_id = miles_ledgers.accrual_request_id;
@@ -0,0 +1,43 @@
+-- Booking class catalogue, maps each class to its cabin and fare family
+CREATE TABLE booking_classes
+(
+    code             TEXT PRIMARY KEY,
+    cabin            TEXT    NOT NULL,
+    fare_family      TEXT    NOT NULL,
+    fare_family_name TEXT    NOT NULL,
+    eligible         BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order       INT     NOT NULL DEFAULT 0,
+    created_at       TIMESTAMPTZ DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ DEFAULT NOW()
+);
+
+INSERT INTO booking_classes (code, cabin, fare_family, fare_family_name, eligible, sort_order)
+VALUES
+-- ---------------- Business ----------------
+('J', 'business', 'business', 'Business', TRUE, 1),
+('C', 'business', 'business', 'Business', TRUE, 2),
+('D', 'business', 'business', 'Business', TRUE, 3),
+('I', 'business', 'business', 'Business', TRUE, 4),
+-- ---------------- Premium Economy ----------------
+('W', 'premium_economy', 'premium_economy', 'Premium Economy', TRUE, 5),
+('Z', 'premium_economy', 'premium_economy', 'Premium Economy', TRUE, 6),
+('U', 'premium_economy', 'premium_economy', 'Premium Economy', TRUE, 7),
+-- ---------------- Economy (Flex) ----------------
+('Y', 'economy', 'flex', 'Economy Flex', TRUE, 8),
+('M', 'economy', 'flex', 'Economy Flex', TRUE, 9),
+('B', 'economy', 'flex', 'Economy Flex', TRUE, 10),
+-- ---------------- Economy (Classic) ----------------
+('S', 'economy', 'classic', 'Economy Classic', TRUE, 11),
+('H', 'economy', 'classic', 'Economy Classic', TRUE, 12),
+('K', 'economy', 'classic', 'Economy Classic', TRUE, 13),
+('L', 'economy', 'classic', 'Economy Classic', TRUE, 14),
+-- ---------------- Economy (Lite) ----------------
+('Q', 'economy', 'lite', 'Economy Lite', TRUE, 15),
+('N', 'economy', 'lite', 'Economy Lite', TRUE, 16),
+('R', 'economy', 'lite', 'Economy Lite', TRUE, 17),
+('T', 'economy', 'lite', 'Economy Lite', TRUE, 18),
+('E', 'economy', 'lite', 'Economy Lite', TRUE, 19),
+-- ---------------- Economy (Super Lite) ----------------
+('A', 'economy', 'super_lite', 'Economy Super Lite', TRUE, 20),
+('P', 'economy', 'super_lite', 'Economy Super Lite', TRUE, 21),
+('G', 'economy', 'super_lite', 'Economy Super Lite', TRUE, 22);
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE accrual_request_segments
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS eligible_spend,
+    DROP COLUMN IF EXISTS earning_mode;
+
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS fare_currency,
+    DROP COLUMN IF EXISTS surcharges,
+    DROP COLUMN IF EXISTS base_fare,
+    DROP COLUMN IF EXISTS earning_mode;
+
+ALTER TABLE accrual_rate_charts
+    DROP COLUMN IF EXISTS revenue_rates,
+    DROP COLUMN IF EXISTS program_currency,
+    DROP COLUMN IF EXISTS earning_mode;
@@ -0,0 +1,29 @@
+-- Charts either earn on distance flown or on the fare paid
+ALTER TABLE accrual_rate_charts
+    ADD COLUMN earning_mode     TEXT  NOT NULL DEFAULT 'distance',
+    ADD COLUMN program_currency TEXT,
+    ADD COLUMN revenue_rates    JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+ALTER TABLE accrual_requests
+    ADD COLUMN earning_mode  TEXT             NOT NULL DEFAULT 'distance',
+    ADD COLUMN base_fare     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN surcharges    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN fare_currency TEXT;
+
+ALTER TABLE accrual_request_segments
+    ADD COLUMN earning_mode   TEXT             NOT NULL DEFAULT 'distance',
+    ADD COLUMN eligible_spend DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN exchange_rate  DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- One unit of from_currency is worth rate units of to_currency from valid_from onwards
+CREATE TABLE exchange_rates
+(
+    id            BIGSERIAL PRIMARY KEY,
+    from_currency TEXT             NOT NULL,
+    to_currency   TEXT             NOT NULL,
+    rate          DOUBLE PRECISION NOT NULL CHECK (rate > 0),
+    valid_from    DATE             NOT NULL,
+    created_at    TIMESTAMPTZ DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (from_currency, to_currency, valid_from)
+);
@@ -0,0 +1,36 @@
+ALTER TABLE campaigns
+    ALTER COLUMN bonus_rate TYPE NUMERIC(10, 2);
+
+ALTER TABLE accrual_request_segments
+    ALTER COLUMN qualifying_accrual_rate TYPE NUMERIC(10, 2),
+    ALTER COLUMN bonus_accrual_rate TYPE NUMERIC(10, 2),
+    ALTER COLUMN tier_bonus_rate TYPE NUMERIC(10, 2);
+
+ALTER TABLE accrual_requests
+    ALTER COLUMN qualifying_accrual_rate TYPE NUMERIC(10, 2),
+    ALTER COLUMN bonus_accrual_rate TYPE NUMERIC(10, 2),
+    ALTER COLUMN tier_bonus_rate TYPE NUMERIC(10, 2);
+
+ALTER TABLE accrual_rates
+    ALTER COLUMN qualifying_rate TYPE NUMERIC(10, 2);
+
+ALTER TABLE campaigns
+    ALTER COLUMN fixed_miles TYPE NUMERIC(10, 2);
+
+ALTER TABLE miles_ledgers
+    ALTER COLUMN qualifying_miles_delta TYPE INT,
+    ALTER COLUMN bonus_miles_delta TYPE INT;
+
+ALTER TABLE accrual_request_segments
+    ALTER COLUMN qualifying_miles TYPE NUMERIC(10, 2),
+    ALTER COLUMN bonus_miles TYPE NUMERIC(10, 2),
+    ALTER COLUMN tier_bonus_miles TYPE NUMERIC(10, 2);
+
+ALTER TABLE accrual_requests
+    ALTER COLUMN qualifying_miles TYPE NUMERIC(10, 2),
+    ALTER COLUMN bonus_miles TYPE NUMERIC(10, 2),
+    ALTER COLUMN tier_bonus_miles TYPE NUMERIC(10, 2);
+
+ALTER TABLE customers
+    ALTER COLUMN qualifying_miles_total TYPE NUMERIC(10, 2),
+    ALTER COLUMN bonus_miles_total TYPE NUMERIC(10, 2);
@@ -0,0 +1,38 @@
+-- Miles are whole numbers everywhere, existing fractions are settled half up
+ALTER TABLE customers
+    ALTER COLUMN qualifying_miles_total TYPE BIGINT USING ROUND(qualifying_miles_total),
+    ALTER COLUMN bonus_miles_total TYPE BIGINT USING ROUND(bonus_miles_total);
+
+ALTER TABLE accrual_requests
+    ALTER COLUMN qualifying_miles TYPE BIGINT USING ROUND(qualifying_miles),
+    ALTER COLUMN bonus_miles TYPE BIGINT USING ROUND(bonus_miles),
+    ALTER COLUMN tier_bonus_miles TYPE BIGINT USING ROUND(tier_bonus_miles);
+
+ALTER TABLE accrual_request_segments
+    ALTER COLUMN qualifying_miles TYPE BIGINT USING ROUND(qualifying_miles),
+    ALTER COLUMN bonus_miles TYPE BIGINT USING ROUND(bonus_miles),
+    ALTER COLUMN tier_bonus_miles TYPE BIGINT USING ROUND(tier_bonus_miles);
+
+ALTER TABLE miles_ledgers
+    ALTER COLUMN qualifying_miles_delta TYPE BIGINT,
+    ALTER COLUMN bonus_miles_delta TYPE BIGINT;
+
+ALTER TABLE campaigns
+    ALTER COLUMN fixed_miles TYPE BIGINT USING ROUND(fixed_miles);
+
+-- Rates multiply whole miles, two decimals would round a rate like 0.125 before it is applied
+ALTER TABLE accrual_rates
+    ALTER COLUMN qualifying_rate TYPE NUMERIC(10, 6);
+
+ALTER TABLE accrual_requests
+    ALTER COLUMN qualifying_accrual_rate TYPE NUMERIC(10, 6),
+    ALTER COLUMN bonus_accrual_rate TYPE NUMERIC(10, 6),
+    ALTER COLUMN tier_bonus_rate TYPE NUMERIC(10, 6);
+
+ALTER TABLE accrual_request_segments
+    ALTER COLUMN qualifying_accrual_rate TYPE NUMERIC(10, 6),
+    ALTER COLUMN bonus_accrual_rate TYPE NUMERIC(10, 6),
+    ALTER COLUMN tier_bonus_rate TYPE NUMERIC(10, 6);
+
+ALTER TABLE campaigns
+    ALTER COLUMN bonus_rate TYPE NUMERIC(10, 6);
@@ -0,0 +1,24 @@
+-- Audit trail of segments whose miles changed when they were priced again
+CREATE TABLE accrual_repricings
+(
+    id                         UUID PRIMARY KEY,
+    run_id                     UUID        NOT NULL,
+    accrual_request_id         UUID        NOT NULL REFERENCES accrual_requests (id),
+    accrual_request_segment_id UUID        NOT NULL REFERENCES accrual_request_segments (id),
+    segment_status             TEXT        NOT NULL,
+    old_accrual_rate_chart_id  UUID,
+    new_accrual_rate_chart_id  UUID,
+    old_qualifying_miles       BIGINT      NOT NULL,
+    new_qualifying_miles       BIGINT      NOT NULL,
+    old_bonus_miles            BIGINT      NOT NULL,
+    new_bonus_miles            BIGINT      NOT NULL,
+    old_tier_bonus_miles       BIGINT      NOT NULL,
+    new_tier_bonus_miles       BIGINT      NOT NULL,
+    miles_ledger_id            UUID REFERENCES miles_ledgers (id),
+    reason                     TEXT        NOT NULL,
+    repriced_by                TEXT        NOT NULL,
+    created_at                 TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_accrual_repricings_run_id ON accrual_repricings (run_id);
+CREATE INDEX idx_accrual_repricings_accrual_request_id ON accrual_repricings (accrual_request_id);
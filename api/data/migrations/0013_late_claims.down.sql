@@ -0,0 +1,3 @@
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS late_claim_approved_by,
+    DROP COLUMN IF EXISTS late_claim_reason;
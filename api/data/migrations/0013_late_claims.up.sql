@@ -0,0 +1,4 @@
+-- Claims accepted by an admin past the claim window keep the reason and who accepted them
+ALTER TABLE accrual_requests
+    ADD COLUMN late_claim_reason      TEXT,
+    ADD COLUMN late_claim_approved_by TEXT;
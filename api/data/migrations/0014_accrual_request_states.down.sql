@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS accrual_request_events;
+
+ALTER TABLE accrual_request_segments DROP CONSTRAINT IF EXISTS chk_accrual_request_segments_status;
+ALTER TABLE accrual_requests DROP CONSTRAINT IF EXISTS chk_accrual_requests_status;
+
+UPDATE accrual_request_segments
+SET status = 'inprogress'
+WHERE status = 'pending';
+
+UPDATE accrual_requests
+SET status = 'inprogress'
+WHERE status IN ('submitted', 'under_review', 'needs_info');
+
+UPDATE accrual_requests
+SET status = 'rejected'
+WHERE status IN ('cancelled', 'reversed');
@@ -0,0 +1,48 @@
+-- Requests get an explicit lifecycle, segments keep a status of their own
+UPDATE accrual_requests
+SET status = CASE
+                 WHEN EXISTS (SELECT 1
+                              FROM accrual_request_segments s
+                              WHERE s.accrual_request_id = accrual_requests.id
+                                AND s.status <> 'inprogress') THEN 'under_review'
+                 ELSE 'submitted' END
+WHERE status = 'inprogress';
+
+UPDATE accrual_request_segments
+SET status = 'pending'
+WHERE status = 'inprogress';
+
+ALTER TABLE accrual_requests
+    ADD CONSTRAINT chk_accrual_requests_status
+        CHECK (status IN ('submitted', 'under_review', 'needs_info', 'approved', 'rejected', 'cancelled', 'reversed'));
+
+ALTER TABLE accrual_request_segments
+    ADD CONSTRAINT chk_accrual_request_segments_status
+        CHECK (status IN ('pending', 'approved', 'rejected'));
+
+-- Every status change of a request, with who made it and why
+CREATE TABLE accrual_request_events
+(
+    id                 UUID PRIMARY KEY,
+    accrual_request_id UUID        NOT NULL REFERENCES accrual_requests (id),
+    from_status        TEXT        NOT NULL,
+    to_status          TEXT        NOT NULL,
+    actor              TEXT        NOT NULL,
+    reason             TEXT,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_accrual_request_events_accrual_request_id ON accrual_request_events (accrual_request_id, created_at);
+
+-- Rebuild the history known so far from the submission and the last review
+INSERT INTO accrual_request_events (id, accrual_request_id, from_status, to_status, actor, reason, created_at)
+SELECT gen_random_uuid(), r.id, '', 'submitted', c.auth0_user_id, r.late_claim_reason, COALESCE(r.created_at, NOW())
+FROM accrual_requests r
+         JOIN customers c ON c.id = r.customer_id;
+
+INSERT INTO accrual_request_events (id, accrual_request_id, from_status, to_status, actor, reason, created_at)
+SELECT gen_random_uuid(), r.id, 'submitted', r.status, r.reviewer_id, r.rejected_reason, r.reviewed_at
+FROM accrual_requests r
+WHERE r.status <> 'submitted'
+  AND r.reviewer_id IS NOT NULL
+  AND r.reviewed_at IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS accrual_request_revisions;
+
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS info_request;
@@ -0,0 +1,18 @@
+-- The reviewer's outstanding question while a request waits for the member
+ALTER TABLE accrual_requests
+    ADD COLUMN info_request TEXT;
+
+-- Each time a member answers a request for more information
+CREATE TABLE accrual_request_revisions
+(
+    id                 UUID PRIMARY KEY,
+    accrual_request_id UUID        NOT NULL REFERENCES accrual_requests (id),
+    revision_no        INT         NOT NULL,
+    info_request       TEXT        NOT NULL,
+    requested_by       TEXT        NOT NULL,
+    requested_at       TIMESTAMPTZ NOT NULL,
+    member_note        TEXT,
+    changes            JSONB       NOT NULL DEFAULT '{}',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (accrual_request_id, revision_no)
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_accrual_requests_assigned_to;
+DROP INDEX IF EXISTS idx_accrual_requests_review_queue;
+
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS assigned_to;
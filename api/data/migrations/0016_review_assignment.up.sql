@@ -0,0 +1,7 @@
+-- Reviewers claim a request for a while so two of them do not decide on it at once
+ALTER TABLE accrual_requests
+    ADD COLUMN assigned_to  TEXT,
+    ADD COLUMN locked_until TIMESTAMPTZ;
+
+CREATE INDEX idx_accrual_requests_review_queue ON accrual_requests (status, created_at);
+CREATE INDEX idx_accrual_requests_assigned_to ON accrual_requests (assigned_to) WHERE assigned_to IS NOT NULL;
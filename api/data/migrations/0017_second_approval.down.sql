@@ -0,0 +1,14 @@
+UPDATE accrual_requests
+SET status = 'under_review'
+WHERE status = 'pending_second_approval';
+
+ALTER TABLE accrual_requests
+    DROP CONSTRAINT chk_accrual_requests_status,
+    ADD CONSTRAINT chk_accrual_requests_status
+        CHECK (status IN ('submitted', 'under_review', 'needs_info', 'approved', 'rejected', 'cancelled', 'reversed'));
+
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS second_approved_at,
+    DROP COLUMN IF EXISTS second_approver_id,
+    DROP COLUMN IF EXISTS first_approved_at,
+    DROP COLUMN IF EXISTS first_approver_id;
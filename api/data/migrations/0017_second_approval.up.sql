@@ -0,0 +1,12 @@
+-- High value claims and manual overrides are approved by two different admins
+ALTER TABLE accrual_requests
+    ADD COLUMN first_approver_id  TEXT,
+    ADD COLUMN first_approved_at  TIMESTAMPTZ,
+    ADD COLUMN second_approver_id TEXT,
+    ADD COLUMN second_approved_at TIMESTAMPTZ;
+
+ALTER TABLE accrual_requests
+    DROP CONSTRAINT chk_accrual_requests_status,
+    ADD CONSTRAINT chk_accrual_requests_status
+        CHECK (status IN ('submitted', 'under_review', 'needs_info', 'pending_second_approval', 'approved', 'rejected',
+                          'cancelled', 'reversed'));
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_accrual_requests_risk_score;
+
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS risk_hits,
+    DROP COLUMN IF EXISTS risk_score;
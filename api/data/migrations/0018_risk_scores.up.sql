@@ -0,0 +1,6 @@
+-- Fraud rules triggered by a claim and the resulting score, reviewers filter the queue on it
+ALTER TABLE accrual_requests
+    ADD COLUMN risk_score INT   NOT NULL DEFAULT 0,
+    ADD COLUMN risk_hits  JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX idx_accrual_requests_risk_score ON accrual_requests (risk_score);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_accrual_requests_auto_approved;
+
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS auto_approved;
+
+DROP TABLE IF EXISTS auto_approval_policies;
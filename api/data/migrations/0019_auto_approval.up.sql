@@ -0,0 +1,21 @@
+-- Which new claims are approved without a reviewer, there is a single row and it starts switched off
+CREATE TABLE auto_approval_policies
+(
+    id                      BIGINT PRIMARY KEY,
+    enabled                 BOOLEAN     NOT NULL DEFAULT FALSE,
+    require_boarding_pass   BOOLEAN     NOT NULL DEFAULT TRUE,
+    require_published_route BOOLEAN     NOT NULL DEFAULT TRUE,
+    max_risk_score          INT         NOT NULL DEFAULT 0,
+    max_miles               BIGINT      NOT NULL DEFAULT 0,
+    member_tiers            JSONB       NOT NULL DEFAULT '[]',
+    updated_by              TEXT,
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO auto_approval_policies (id)
+VALUES (1);
+
+ALTER TABLE accrual_requests
+    ADD COLUMN auto_approved BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_accrual_requests_auto_approved ON accrual_requests (reviewed_at) WHERE auto_approved;
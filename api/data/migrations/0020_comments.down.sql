@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_events;
+
+DROP TABLE IF EXISTS accrual_request_comments;
@@ -0,0 +1,29 @@
+-- Conversation between the member and reviewers, internal comments are hidden from the member
+CREATE TABLE accrual_request_comments
+(
+    id                 UUID PRIMARY KEY,
+    accrual_request_id UUID        NOT NULL REFERENCES accrual_requests (id),
+    author_id          TEXT        NOT NULL,
+    author_role        TEXT        NOT NULL CHECK (author_role IN ('member', 'admin')),
+    body               TEXT        NOT NULL,
+    internal           BOOLEAN     NOT NULL DEFAULT FALSE,
+    attachments        JSONB       NOT NULL DEFAULT '[]',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_accrual_request_comments_accrual_request_id ON accrual_request_comments (accrual_request_id, created_at);
+
+-- Outbox of notifications, the sender delivers the unpublished rows in order
+CREATE TABLE notification_events
+(
+    id                 UUID PRIMARY KEY,
+    kind               TEXT        NOT NULL,
+    audience           TEXT        NOT NULL CHECK (audience IN ('member', 'reviewers')),
+    recipient_id       TEXT,
+    accrual_request_id UUID REFERENCES accrual_requests (id),
+    payload            JSONB       NOT NULL DEFAULT '{}',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at       TIMESTAMPTZ
+);
+
+CREATE INDEX idx_notification_events_unpublished ON notification_events (created_at) WHERE published_at IS NULL;
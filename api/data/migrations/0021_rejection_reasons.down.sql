@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_accrual_request_segments_rejected_reason_code;
+
+ALTER TABLE accrual_request_segments
+    DROP COLUMN IF EXISTS rejected_reason_code,
+    DROP COLUMN IF EXISTS rejected_note;
+
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS rejected_reason_code,
+    DROP COLUMN IF EXISTS rejected_note;
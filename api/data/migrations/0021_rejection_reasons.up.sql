@@ -0,0 +1,21 @@
+-- Rejections carry a reason code from the catalogue, the free text reason is kept for display
+ALTER TABLE accrual_requests
+    ADD COLUMN rejected_reason_code TEXT,
+    ADD COLUMN rejected_note        TEXT;
+
+ALTER TABLE accrual_request_segments
+    ADD COLUMN rejected_reason_code TEXT,
+    ADD COLUMN rejected_note        TEXT;
+
+-- Reasons written before the catalogue become notes of the 'other' code
+UPDATE accrual_requests
+SET rejected_reason_code = 'other',
+    rejected_note        = rejected_reason
+WHERE rejected_reason IS NOT NULL;
+
+UPDATE accrual_request_segments
+SET rejected_reason_code = 'other',
+    rejected_note        = rejected_reason
+WHERE rejected_reason IS NOT NULL;
+
+CREATE INDEX idx_accrual_request_segments_rejected_reason_code ON accrual_request_segments (rejected_reason_code) WHERE rejected_reason_code IS NOT NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_miles_ledgers_pending;
+DROP INDEX IF EXISTS idx_miles_ledgers_reference_id;
+
+ALTER TABLE miles_ledgers
+    DROP COLUMN IF EXISTS reference_id,
+    DROP COLUMN IF EXISTS posted_at,
+    DROP COLUMN IF EXISTS posting_error;
@@ -0,0 +1,12 @@
+-- Ledger rows are written before the points account moves and marked posted once it has
+ALTER TABLE miles_ledgers
+    ADD COLUMN reference_id  TEXT,
+    ADD COLUMN posted_at     TIMESTAMPTZ,
+    ADD COLUMN posting_error TEXT;
+
+-- Rows written before were only saved after the points account had moved
+UPDATE miles_ledgers
+SET posted_at = created_at;
+
+CREATE UNIQUE INDEX idx_miles_ledgers_reference_id ON miles_ledgers (reference_id) WHERE reference_id IS NOT NULL;
+CREATE INDEX idx_miles_ledgers_pending ON miles_ledgers (accrual_request_id) WHERE posted_at IS NULL;
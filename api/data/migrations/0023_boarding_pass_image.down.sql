@@ -0,0 +1,2 @@
+ALTER TABLE auto_approval_policies
+    RENAME COLUMN require_boarding_pass_image TO require_boarding_pass;
@@ -0,0 +1,3 @@
+-- The condition only checks that an image was uploaded, nobody has verified it
+ALTER TABLE auto_approval_policies
+    RENAME COLUMN require_boarding_pass TO require_boarding_pass_image;
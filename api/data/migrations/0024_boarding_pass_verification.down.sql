@@ -0,0 +1,6 @@
+ALTER TABLE auto_approval_policies
+    RENAME COLUMN require_verified_boarding_pass TO require_boarding_pass_image;
+
+ALTER TABLE accrual_requests
+    DROP COLUMN IF EXISTS boarding_pass_verified_by,
+    DROP COLUMN IF EXISTS boarding_pass_verified_at;
@@ -0,0 +1,7 @@
+-- A reviewer marks the boarding pass as checked, auto approval can require it
+ALTER TABLE accrual_requests
+    ADD COLUMN boarding_pass_verified_by TEXT,
+    ADD COLUMN boarding_pass_verified_at TIMESTAMPTZ;
+
+ALTER TABLE auto_approval_policies
+    RENAME COLUMN require_boarding_pass_image TO require_verified_boarding_pass;
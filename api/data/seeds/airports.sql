@@ -0,0 +1,82 @@
+INSERT INTO airports (code, name, city, country, timezone, latitude, longitude)
+VALUES
+-- Vietnam
+('BMV', 'Buon Ma Thuot Airport', 'Buon Ma Thuot', 'VN', 'Asia/Ho_Chi_Minh', 12.6683, 108.1203),
+('CXR', 'Cam Ranh International Airport', 'Nha Trang', 'VN', 'Asia/Ho_Chi_Minh', 11.9982, 109.2194),
+('DAD', 'Da Nang International Airport', 'Da Nang', 'VN', 'Asia/Ho_Chi_Minh', 16.0439, 108.1992),
+('DIN', 'Dien Bien Phu Airport', 'Dien Bien Phu', 'VN', 'Asia/Ho_Chi_Minh', 21.3975, 103.0078),
+('DLI', 'Lien Khuong Airport', 'Da Lat', 'VN', 'Asia/Ho_Chi_Minh', 11.7500, 108.3670),
+('HAN', 'Noi Bai International Airport', 'Ha Noi', 'VN', 'Asia/Ho_Chi_Minh', 21.2212, 105.8072),
+('HPH', 'Cat Bi International Airport', 'Hai Phong', 'VN', 'Asia/Ho_Chi_Minh', 20.8194, 106.7250),
+('HUI', 'Phu Bai International Airport', 'Hue', 'VN', 'Asia/Ho_Chi_Minh', 16.4015, 107.7030),
+('PQC', 'Phu Quoc International Airport', 'Phu Quoc', 'VN', 'Asia/Ho_Chi_Minh', 10.1698, 103.9931),
+('PXU', 'Pleiku Airport', 'Pleiku', 'VN', 'Asia/Ho_Chi_Minh', 14.0045, 108.0172),
+('SGN', 'Tan Son Nhat International Airport', 'Ho Chi Minh City', 'VN', 'Asia/Ho_Chi_Minh', 10.8188, 106.6519),
+('TBB', 'Tuy Hoa Airport', 'Tuy Hoa', 'VN', 'Asia/Ho_Chi_Minh', 13.0496, 109.3337),
+('THD', 'Tho Xuan Airport', 'Thanh Hoa', 'VN', 'Asia/Ho_Chi_Minh', 19.9017, 105.4678),
+('UIH', 'Phu Cat Airport', 'Quy Nhon', 'VN', 'Asia/Ho_Chi_Minh', 13.9550, 109.0422),
+('VCA', 'Can Tho International Airport', 'Can Tho', 'VN', 'Asia/Ho_Chi_Minh', 10.0851, 105.7117),
+('VCL', 'Chu Lai Airport', 'Tam Ky', 'VN', 'Asia/Ho_Chi_Minh', 15.4033, 108.7060),
+('VDH', 'Dong Hoi Airport', 'Dong Hoi', 'VN', 'Asia/Ho_Chi_Minh', 17.5150, 106.5906),
+('VDO', 'Van Don International Airport', 'Ha Long', 'VN', 'Asia/Ho_Chi_Minh', 21.1178, 107.4142),
+('VII', 'Vinh International Airport', 'Vinh', 'VN', 'Asia/Ho_Chi_Minh', 18.7376, 105.6708),
+
+-- Asia
+('BKK', 'Suvarnabhumi Airport', 'Bangkok', 'TH', 'Asia/Bangkok', 13.6900, 100.7501),
+('BLR', 'Kempegowda International Airport', 'Bengaluru', 'IN', 'Asia/Kolkata', 13.1986, 77.7066),
+('CAN', 'Guangzhou Baiyun International Airport', 'Guangzhou', 'CN', 'Asia/Shanghai', 23.3924, 113.2988),
+('CTU', 'Chengdu Shuangliu International Airport', 'Chengdu', 'CN', 'Asia/Shanghai', 30.5785, 103.9471),
+('DEL', 'Indira Gandhi International Airport', 'New Delhi', 'IN', 'Asia/Kolkata', 28.5562, 77.1000),
+('DPS', 'Ngurah Rai International Airport', 'Denpasar', 'ID', 'Asia/Makassar', -8.7482, 115.1672),
+('FUK', 'Fukuoka Airport', 'Fukuoka', 'JP', 'Asia/Tokyo', 33.5859, 130.4510),
+('HGH', 'Hangzhou Xiaoshan International Airport', 'Hangzhou', 'CN', 'Asia/Shanghai', 30.2295, 120.4344),
+('HKG', 'Hong Kong International Airport', 'Hong Kong', 'HK', 'Asia/Hong_Kong', 22.3080, 113.9185),
+('HKT', 'Phuket International Airport', 'Phuket', 'TH', 'Asia/Bangkok', 8.1132, 98.3169),
+('HND', 'Tokyo Haneda Airport', 'Tokyo', 'JP', 'Asia/Tokyo', 35.5494, 139.7798),
+('HYD', 'Rajiv Gandhi International Airport', 'Hyderabad', 'IN', 'Asia/Kolkata', 17.2403, 78.4294),
+('ICN', 'Incheon International Airport', 'Seoul', 'KR', 'Asia/Seoul', 37.4602, 126.4407),
+('JKT', 'Jakarta (all airports)', 'Jakarta', 'ID', 'Asia/Jakarta', -6.1256, 106.6559),
+('KHH', 'Kaohsiung International Airport', 'Kaohsiung', 'TW', 'Asia/Taipei', 22.5771, 120.3500),
+('KIX', 'Kansai International Airport', 'Osaka', 'JP', 'Asia/Tokyo', 34.4320, 135.2304),
+('KUL', 'Kuala Lumpur International Airport', 'Kuala Lumpur', 'MY', 'Asia/Kuala_Lumpur', 2.7456, 101.7072),
+('LPQ', 'Luang Prabang International Airport', 'Luang Prabang', 'LA', 'Asia/Vientiane', 19.8973, 102.1608),
+('MFM', 'Macau International Airport', 'Macau', 'MO', 'Asia/Macau', 22.1496, 113.5917),
+('MNL', 'Ninoy Aquino International Airport', 'Manila', 'PH', 'Asia/Manila', 14.5086, 121.0194),
+('NGO', 'Chubu Centrair International Airport', 'Nagoya', 'JP', 'Asia/Tokyo', 34.8584, 136.8054),
+('NRT', 'Narita International Airport', 'Tokyo', 'JP', 'Asia/Tokyo', 35.7720, 140.3929),
+('PEK', 'Beijing Capital International Airport', 'Beijing', 'CN', 'Asia/Shanghai', 40.0799, 116.6031),
+('PNH', 'Phnom Penh International Airport', 'Phnom Penh', 'KH', 'Asia/Phnom_Penh', 11.5466, 104.8441),
+('PUS', 'Gimhae International Airport', 'Busan', 'KR', 'Asia/Seoul', 35.1795, 128.9382),
+('PVG', 'Shanghai Pudong International Airport', 'Shanghai', 'CN', 'Asia/Shanghai', 31.1443, 121.8083),
+('REP', 'Siem Reap International Airport', 'Siem Reap', 'KH', 'Asia/Phnom_Penh', 13.4107, 103.8128),
+('RGN', 'Yangon International Airport', 'Yangon', 'MM', 'Asia/Yangon', 16.9073, 96.1332),
+('RMQ', 'Taichung International Airport', 'Taichung', 'TW', 'Asia/Taipei', 24.2647, 120.6208),
+('SIN', 'Singapore Changi Airport', 'Singapore', 'SG', 'Asia/Singapore', 1.3644, 103.9915),
+('SZX', 'Shenzhen Bao''an International Airport', 'Shenzhen', 'CN', 'Asia/Shanghai', 22.6393, 113.8107),
+('TPE', 'Taiwan Taoyuan International Airport', 'Taipei', 'TW', 'Asia/Taipei', 25.0797, 121.2342),
+('VTE', 'Wattay International Airport', 'Vientiane', 'LA', 'Asia/Vientiane', 17.9883, 102.5633),
+
+-- Europe
+('CDG', 'Paris Charles de Gaulle Airport', 'Paris', 'FR', 'Europe/Paris', 49.0097, 2.5479),
+('FRA', 'Frankfurt Airport', 'Frankfurt', 'DE', 'Europe/Berlin', 50.0379, 8.5622),
+('LHR', 'London Heathrow Airport', 'London', 'GB', 'Europe/London', 51.4700, -0.4543),
+('MIL', 'Milan (all airports)', 'Milan', 'IT', 'Europe/Rome', 45.6306, 8.7281),
+('MUC', 'Munich Airport', 'Munich', 'DE', 'Europe/Berlin', 48.3537, 11.7750),
+('SVO', 'Sheremetyevo International Airport', 'Moscow', 'RU', 'Europe/Moscow', 55.9726, 37.4146),
+
+-- Oceania
+('MEL', 'Melbourne Airport', 'Melbourne', 'AU', 'Australia/Melbourne', -37.6690, 144.8410),
+('PER', 'Perth Airport', 'Perth', 'AU', 'Australia/Perth', -31.9385, 115.9672),
+('SYD', 'Sydney Kingsford Smith Airport', 'Sydney', 'AU', 'Australia/Sydney', -33.9399, 151.1753),
+
+-- North America
+('LAX', 'Los Angeles International Airport', 'Los Angeles', 'US', 'America/Los_Angeles', 33.9416, -118.4085),
+('SFO', 'San Francisco International Airport', 'San Francisco', 'US', 'America/Los_Angeles', 37.6213, -122.3790),
+('YVR', 'Vancouver International Airport', 'Vancouver', 'CA', 'America/Vancouver', 49.1967, -123.1815)
+ON CONFLICT (code) DO UPDATE SET name       = EXCLUDED.name,
+                                 city       = EXCLUDED.city,
+                                 country    = EXCLUDED.country,
+                                 timezone   = EXCLUDED.timezone,
+                                 latitude   = EXCLUDED.latitude,
+                                 longitude  = EXCLUDED.longitude,
+                                 updated_at = NOW();
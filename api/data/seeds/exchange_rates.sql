@@ -0,0 +1,22 @@
+INSERT INTO exchange_rates (from_currency, to_currency, rate, valid_from)
+VALUES
+-- Into USD, the program currency of revenue based charts
+('VND', 'USD', 0.0000393, '2025-01-01'),
+('EUR', 'USD', 1.0350000, '2025-01-01'),
+('GBP', 'USD', 1.2500000, '2025-01-01'),
+('JPY', 'USD', 0.0063500, '2025-01-01'),
+('KRW', 'USD', 0.0006800, '2025-01-01'),
+('SGD', 'USD', 0.7330000, '2025-01-01'),
+('THB', 'USD', 0.0292000, '2025-01-01'),
+('AUD', 'USD', 0.6200000, '2025-01-01'),
+('VND', 'USD', 0.0000382, '2025-07-01'),
+('EUR', 'USD', 1.1700000, '2025-07-01'),
+('GBP', 'USD', 1.3600000, '2025-07-01'),
+('JPY', 'USD', 0.0069000, '2025-07-01'),
+('KRW', 'USD', 0.0007300, '2025-07-01'),
+('SGD', 'USD', 0.7840000, '2025-07-01'),
+('THB', 'USD', 0.0307000, '2025-07-01'),
+('AUD', 'USD', 0.6540000, '2025-07-01')
+ON CONFLICT (from_currency, to_currency, valid_from) DO UPDATE
+    SET rate       = EXCLUDED.rate,
+        updated_at = NOW();
@@ -1,14 +1,21 @@
 package config
 
+import (
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+)
+
 type Config struct {
-	ServerName string         `mapstructure:"SERVER_NAME"`
-	Web        WebConfig      `mapstructure:"WEB"`
-	Cors       CorsConfig     `mapstructure:"CORS"`
-	Database   DatabaseConfig `mapstructure:"DATABASE"`
-	Auth0      Auth0Config    `mapstructure:"AUTH0"`
-	UserAPI    Auth0Config    `mapstructure:"USER_API"`
-	SessionM   SessionMConfig `mapstructure:"SESSION_M"`
-	SentryDSN  string         `mapstructure:"SENTRY_DSN"`
+	ServerName string          `mapstructure:"SERVER_NAME"`
+	Web        WebConfig       `mapstructure:"WEB"`
+	Cors       CorsConfig      `mapstructure:"CORS"`
+	Database   DatabaseConfig  `mapstructure:"DATABASE"`
+	Auth0      Auth0Config     `mapstructure:"AUTH0"`
+	UserAPI    Auth0Config     `mapstructure:"USER_API"`
+	SessionM   SessionMConfig  `mapstructure:"SESSION_M"`
+	PublicAPI  PublicAPIConfig `mapstructure:"PUBLIC_API"`
+	Accrual    AccrualConfig   `mapstructure:"ACCRUAL"`
+	Risk       RiskConfig      `mapstructure:"RISK"`
+	SentryDSN  string          `mapstructure:"SENTRY_DSN"`
 }
 
 type WebConfig struct {
@@ -28,6 +35,25 @@ type CorsConfig struct {
 	AllowCredentials bool     `mapstructure:"ALLOW_CREDENTIALS"`
 }
 
+type PublicAPIConfig struct {
+	RateLimitPerMinute int `mapstructure:"RATE_LIMIT_PER_MINUTE"` // Per client IP, defaults to 30
+}
+
+type AccrualConfig struct {
+	Rounding            miles.Rounding `mapstructure:"ROUNDING"`              // half_up (default), half_even, down, up
+	ClaimMaxAgeDays     int            `mapstructure:"CLAIM_MAX_AGE_DAYS"`    // Days after departure a flight can be claimed, defaults to 180
+	ClaimMinDelayHours  int            `mapstructure:"CLAIM_MIN_DELAY_HOURS"` // Hours after departure before a flight can be claimed, defaults to 24
+	BatchConcurrency    int            `mapstructure:"BATCH_CONCURRENCY"`     // Requests reviewed at the same time by a bulk action, defaults to 4
+	ReviewLockMinutes   int            `mapstructure:"REVIEW_LOCK_MINUTES"`   // How long a reviewer keeps a claimed request, defaults to 30
+	ReviewSLAHours      int            `mapstructure:"REVIEW_SLA_HOURS"`      // Hours after submission a request should be decided in, defaults to 48
+	SecondApprovalMiles miles.Miles    `mapstructure:"SECOND_APPROVAL_MILES"` // Claims worth at least this many miles need a second admin, defaults to 20000
+}
+
+type RiskConfig struct {
+	HighRiskScore   int `mapstructure:"HIGH_RISK_SCORE"`   // Claims scoring at least this are kept out of bulk and auto approval, defaults to 60
+	MaxWeeklyClaims int `mapstructure:"MAX_WEEKLY_CLAIMS"` // Claims a member can file in 7 days before it looks suspicious, defaults to 5
+}
+
 type DatabaseConfig struct {
 	URL          string `mapstructure:"URL"`
 	MaxOpenConns int    `mapstructure:"MAX_OPEN_CONNS"`
@@ -49,6 +75,7 @@ type SessionMConfig struct {
 	RetailerID       string `mapstructure:"RETAILER_ID"`
 	PointSourceID    string `mapstructure:"POINT_SOURCE_ID"`
 	PointAccountID   string `mapstructure:"POINT_ACCOUNT_ID"`
+	BonusAccountID   string `mapstructure:"BONUS_POINT_ACCOUNT_ID"` // Required once any claim or promotion earns bonus miles
 	TierSystemID     string `mapstructure:"TIER_SYSTEM_ID"`
 	IncentivesAPIURL string `mapstructure:"INCENTIVES_API_URL"`
 	IncentivesAppKey string `mapstructure:"INCENTIVES_APP_KEY"`
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	CampaignStatusDraft     = "draft"
+	CampaignStatusScheduled = "scheduled"
+	CampaignStatusPaused    = "paused"
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	DistanceSourcePublished = "published"
+	DistanceSourceComputed  = "computed"
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	EarningModeDistance = "distance"
+	EarningModeRevenue  = "revenue"
+)
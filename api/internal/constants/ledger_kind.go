@@ -0,0 +1,9 @@
+package constants
+
+const (
+	LedgerKindAccrual    = "accrual"
+	LedgerKindPromotion  = "promotion"
+	LedgerKindAdjustment = "adjustment"
+	LedgerKindExpire     = "expire"
+	LedgerKindCorrection = "correction"
+)
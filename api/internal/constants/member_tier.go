@@ -1,5 +1,9 @@
 package constants
 
+import (
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+)
+
 const (
 	MemberTierRegister     = "register"
 	MemberTierSilver       = "silver"
@@ -12,7 +16,7 @@ const (
 // MembershipTierCondition represents the qualifying miles threshold for each tier
 type MembershipTierCondition struct {
 	Tier     string
-	MinMiles float64
+	MinMiles miles.Miles
 }
 
 // MembershipTierConditions defines the upgrade thresholds for each tier
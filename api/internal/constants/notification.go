@@ -0,0 +1,10 @@
+package constants
+
+const (
+	NotificationKindCommentAdded = "accrual_request.comment_added"
+)
+
+const (
+	NotificationAudienceMember    = "member"
+	NotificationAudienceReviewers = "reviewers"
+)
@@ -0,0 +1,24 @@
+package constants
+
+const (
+	RejectionReasonTicketNotFound     = "ticket_not_found"
+	RejectionReasonNameMismatch       = "name_mismatch"
+	RejectionReasonClassIneligible    = "class_ineligible"
+	RejectionReasonOutsideClaimWindow = "outside_claim_window"
+	RejectionReasonDuplicateClaim     = "duplicate_claim"
+	RejectionReasonFlightNotFlown     = "flight_not_flown"
+	RejectionReasonMissingDocuments   = "missing_documents"
+	RejectionReasonOther              = "other" // Needs a note, the note is shown as the reason
+)
+
+// RejectionReasons is the catalogue of reason codes a reviewer can reject with, the messages are in resources/i18n
+var RejectionReasons = []string{
+	RejectionReasonTicketNotFound,
+	RejectionReasonNameMismatch,
+	RejectionReasonClassIneligible,
+	RejectionReasonOutsideClaimWindow,
+	RejectionReasonDuplicateClaim,
+	RejectionReasonFlightNotFlown,
+	RejectionReasonMissingDocuments,
+	RejectionReasonOther,
+}
@@ -1,7 +1,20 @@
 package constants
 
+// Accrual request statuses, allowed moves between them live in the mileage service state machine
 const (
-	RequestStatusInProgress = "inprogress"
-	RequestStatusApproved   = "approved"
-	RequestStatusRejected   = "rejected"
+	RequestStatusSubmitted             = "submitted"
+	RequestStatusUnderReview           = "under_review" // Some segments are decided, others are still pending
+	RequestStatusNeedsInfo             = "needs_info"
+	RequestStatusPendingSecondApproval = "pending_second_approval" // Approved once, waiting for a different admin
+	RequestStatusApproved              = "approved"                // At least one segment was credited
+	RequestStatusRejected              = "rejected"
+	RequestStatusCancelled             = "cancelled"
+	RequestStatusReversed              = "reversed"
+)
+
+// Accrual request segment statuses, each segment of an itinerary is decided on its own
+const (
+	SegmentStatusPending  = "pending"
+	SegmentStatusApproved = "approved"
+	SegmentStatusRejected = "rejected"
 )
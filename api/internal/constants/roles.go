@@ -4,3 +4,6 @@ const (
 	UserRoleAdmin  = "admin"
 	UserRoleMember = "member"
 )
+
+// SystemReviewerID is recorded as the reviewer of requests the auto approval policy approved
+const SystemReviewerID = "system:auto-approval"
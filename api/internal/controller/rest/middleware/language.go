@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/viebiz/lit"
+)
+
+const HeaderLanguage = "X-Language-key"
+
+// supportedLanguages have a message bundle in resources/i18n, the first one is the default
+var supportedLanguages = []string{"en", "vi"}
+
+// Language narrows the caller's language to one with a message bundle, so 'vi-VN' reads as 'vi' and anything unknown as English
+func Language() lit.HandlerFunc {
+	return func(c lit.Context) error {
+		req := c.Request()
+		lang := strings.ToLower(strings.TrimSpace(req.Header.Get(HeaderLanguage)))
+
+		resolved := supportedLanguages[0]
+		for _, supported := range supportedLanguages {
+			if lang == supported || strings.HasPrefix(lang, supported+"-") {
+				resolved = supported
+				break
+			}
+		}
+		req.Header.Set(HeaderLanguage, resolved)
+
+		c.Next()
+
+		return nil
+	}
+}
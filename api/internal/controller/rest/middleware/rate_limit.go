@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/viebiz/lit"
+)
+
+const headerForwardedFor = "X-Forwarded-For"
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimit allows at most limit requests per client IP in every window, counted in memory per instance
+func RateLimit(limit int, window time.Duration) lit.HandlerFunc {
+	var (
+		mu      sync.Mutex
+		windows = map[string]*rateWindow{}
+		sweptAt = time.Now()
+	)
+
+	return func(c lit.Context) error {
+		now := time.Now()
+		key := clientIP(c.Request())
+
+		mu.Lock()
+		// Drop expired windows now and then so idle clients do not pile up
+		if now.Sub(sweptAt) > window {
+			for k, w := range windows {
+				if now.Sub(w.start) > window {
+					delete(windows, k)
+				}
+			}
+			sweptAt = now
+		}
+
+		w, ok := windows[key]
+		if !ok || now.Sub(w.start) > window {
+			w = &rateWindow{start: now}
+			windows[key] = w
+		}
+		w.count++
+		exceeded := w.count > limit
+		retryAfter := w.start.Add(window).Sub(now)
+		mu.Unlock()
+
+		if exceeded {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			return lit.HTTPError{Status: http.StatusTooManyRequests, Code: "too_many_requests", Desc: "Too many requests"}
+		}
+
+		c.Next()
+
+		return nil
+	}
+}
+
+func clientIP(r *http.Request) string {
+	// The API runs behind a load balancer, which appends the address it saw last in the forwarded chain.
+	// Anything before it is sent by the client and can be forged to dodge the limit
+	if forwarded := r.Header.Values(headerForwardedFor); len(forwarded) > 0 {
+		chain := strings.Split(forwarded[len(forwarded)-1], ",")
+		if ip := strings.TrimSpace(chain[len(chain)-1]); ip != "" {
+			return ip
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
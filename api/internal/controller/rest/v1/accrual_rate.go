@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) GetAccrualRateCharts(c lit.Context) error {
+	var req dto.AccrualRateChartFilter
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, total, err := s.accrualRate.GetCharts(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": total,
+	})
+}
+
+func (s Controller) GetAccrualRateChart(c lit.Context) error {
+	var req dto.AccrualRateChartIDInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	chart, err := s.accrualRate.GetChart(c, req.ID)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, chart)
+}
+
+func (s Controller) CreateAccrualRateChart(c lit.Context) error {
+	var req dto.AccrualRateChartInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	chart, err := s.accrualRate.CreateChart(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, chart)
+}
+
+func (s Controller) UpdateAccrualRateChart(c lit.Context) error {
+	var req dto.AccrualRateChartInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	chart, err := s.accrualRate.UpdateChart(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, chart)
+}
+
+func (s Controller) DeleteAccrualRateChart(c lit.Context) error {
+	var req dto.AccrualRateChartIDInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.accrualRate.DeleteChart(c, req.ID); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "delete successfully"})
+}
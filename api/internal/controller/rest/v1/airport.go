@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) SearchAirports(c lit.Context) error {
+	var req dto.AirportFilter
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.airport.SearchAirports(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": len(data),
+	})
+}
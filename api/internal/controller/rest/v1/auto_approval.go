@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) GetAutoApprovalPolicy(c lit.Context) error {
+	data, err := s.mileage.GetAutoApprovalPolicy(c)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s Controller) UpdateAutoApprovalPolicy(c lit.Context) error {
+	var req dto.AutoApprovalPolicyInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.UpdateAutoApprovalPolicy(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s Controller) DisableAutoApproval(c lit.Context) error {
+	if err := s.mileage.DisableAutoApproval(c); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "disable auto approval successfully"})
+}
+
+func (s Controller) GetAutoApprovalReport(c lit.Context) error {
+	var req dto.AutoApprovalReportFilter
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.GetAutoApprovalReport(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) GetBookingClasses(c lit.Context) error {
+	data, err := s.bookingClass.GetBookingClasses(c)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": len(data),
+	})
+}
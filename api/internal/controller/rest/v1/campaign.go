@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) GetCampaigns(c lit.Context) error {
+	var req dto.CampaignFilter
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, total, err := s.campaign.GetCampaigns(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": total,
+	})
+}
+
+func (s Controller) GetCampaign(c lit.Context) error {
+	var req dto.CampaignIDInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	campaign, err := s.campaign.GetCampaign(c, req.ID)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, campaign)
+}
+
+func (s Controller) CreateCampaign(c lit.Context) error {
+	var req dto.CampaignInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	campaign, err := s.campaign.CreateCampaign(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, campaign)
+}
+
+func (s Controller) UpdateCampaign(c lit.Context) error {
+	var req dto.CampaignInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	campaign, err := s.campaign.UpdateCampaign(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, campaign)
+}
+
+func (s Controller) ScheduleCampaign(c lit.Context) error {
+	var req dto.CampaignIDInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.campaign.ScheduleCampaign(c, req.ID); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "schedule successfully"})
+}
+
+func (s Controller) PauseCampaign(c lit.Context) error {
+	var req dto.CampaignIDInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.campaign.PauseCampaign(c, req.ID); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "pause successfully"})
+}
+
+func (s Controller) GetCampaignReport(c lit.Context) error {
+	var req dto.CampaignIDInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	report, err := s.campaign.GetCampaignReport(c, req.ID)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+func (s Controller) GetRunningCampaigns(c lit.Context) error {
+	data, err := s.campaign.GetRunningCampaigns(c)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": len(data),
+	})
+}
+
+func (s Controller) OptInCampaign(c lit.Context) error {
+	var req dto.CampaignIDInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.campaign.OptIn(c, req.ID); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "opt-in successfully"})
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) GetMyComments(c lit.Context) error {
+	var req dto.GetCommentsInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.GetMyComments(c, req.ID)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": len(data),
+	})
+}
+
+func (s Controller) AddMyComment(c lit.Context) error {
+	var req dto.CommentInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.AddMyComment(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s Controller) GetComments(c lit.Context) error {
+	var req dto.GetCommentsInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.GetComments(c, req.ID)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": len(data),
+	})
+}
+
+func (s Controller) AddComment(c lit.Context) error {
+	var req dto.AdminCommentInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.AddComment(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -4,6 +4,10 @@ import (
 	"net/http"
 
 	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/accrualrate"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/airport"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/bookingclass"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/campaign"
 	"github.com/erwin-lovecraft/aegismiles/internal/services/customer"
 	"github.com/erwin-lovecraft/aegismiles/internal/services/mileage"
 	"github.com/viebiz/lit"
@@ -11,14 +15,22 @@ import (
 )
 
 type Controller struct {
-	customer customer.Service
-	mileage  mileage.Service
+	customer     customer.Service
+	mileage      mileage.Service
+	accrualRate  accrualrate.Service
+	campaign     campaign.Service
+	airport      airport.Service
+	bookingClass bookingclass.Service
 }
 
-func New(customer customer.Service, mileage mileage.Service) Controller {
+func New(customer customer.Service, mileage mileage.Service, accrualRate accrualrate.Service, campaign campaign.Service, airport airport.Service, bookingClass bookingclass.Service) Controller {
 	return Controller{
-		customer: customer,
-		mileage:  mileage,
+		customer:     customer,
+		mileage:      mileage,
+		accrualRate:  accrualRate,
+		campaign:     campaign,
+		airport:      airport,
+		bookingClass: bookingClass,
 	}
 }
 
@@ -38,8 +50,51 @@ func convertErr(err error) error {
 	case "accrual request already exists",
 		"accrual request does not exists",
 		"invalid status",
-		"user not found":
+		"user not found",
+		"carrier is not eligible for accrual",
+		"route distance is not available",
+		"airport code is not recognised",
+		"accrual request segment does not exists",
+		"invalid itinerary",
+		"fare is required for revenue earning",
+		"exchange rate is not available",
+		"accrual rate chart does not exists",
+		"accrual rate chart is already in force",
+		"accrual rate chart cannot end in the past",
+		"accrual rate chart overlaps an existing chart",
+		"invalid accrual rate chart",
+		"campaign does not exists",
+		"campaign has already ended",
+		"campaign is not open for opt-in",
+		"invalid campaign",
+		"invalid repricing filter",
+		"repricing reason is required",
+		"resubmission has no changes",
+		"boarding pass is missing",
+		"reversal reason is required",
+		"accrual request is not locked by you",
+		"invalid report period",
+		"accrual request needs a second approval",
+		"high risk request must be approved individually":
 		return lit.HTTPError{Status: http.StatusBadRequest, Code: "invalid_request", Desc: err.Error()}
+	case "booking class is unknown":
+		return lit.HTTPError{Status: http.StatusBadRequest, Code: "booking_class.unknown", Desc: err.Error()}
+	case "booking class is not eligible for accrual":
+		return lit.HTTPError{Status: http.StatusBadRequest, Code: "booking_class.ineligible", Desc: err.Error()}
+	case "accrual request is locked by another reviewer":
+		return lit.HTTPError{Status: http.StatusConflict, Code: "review.locked", Desc: err.Error()}
+	case "accrual request was changed by someone else":
+		return lit.HTTPError{Status: http.StatusConflict, Code: "review.conflict", Desc: err.Error()}
+	case "second approval must come from a different admin":
+		return lit.HTTPError{Status: http.StatusForbidden, Code: "four_eyes.same_approver", Desc: err.Error()}
+	case "miles have already been spent":
+		return lit.HTTPError{Status: http.StatusBadRequest, Code: "reversal.insufficient_balance", Desc: err.Error()}
+	case "departure date is in the future":
+		return lit.HTTPError{Status: http.StatusBadRequest, Code: "claim_window.future_departure", Desc: err.Error()}
+	case "flight is too recent to claim":
+		return lit.HTTPError{Status: http.StatusBadRequest, Code: "claim_window.too_early", Desc: err.Error()}
+	case "claim window has expired":
+		return lit.HTTPError{Status: http.StatusBadRequest, Code: "claim_window.expired", Desc: err.Error()}
 	default:
 		return err
 	}
@@ -79,6 +134,34 @@ func (s Controller) GetAccrualRequests(c lit.Context) error {
 	})
 }
 
+func (s Controller) GetMyAccrualRequest(c lit.Context) error {
+	var req dto.GetAccrualRequestInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.GetMyAccrualRequest(c, req.ID)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s Controller) GetAccrualRequest(c lit.Context) error {
+	var req dto.GetAccrualRequestInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.GetAccrualRequest(c, req.ID)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
 func (s Controller) SubmitAccrualRequest(c lit.Context) error {
 	var req dto.AccrualRequestInput
 	if err := c.Bind(&req); err != nil {
@@ -92,6 +175,60 @@ func (s Controller) SubmitAccrualRequest(c lit.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{"message": "submit successfully"})
 }
 
+func (s Controller) QuoteAccrual(c lit.Context) error {
+	var req dto.AccrualQuoteInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	quote, err := s.mileage.QuoteAccrual(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, quote)
+}
+
+func (s Controller) QuotePublicAccrual(c lit.Context) error {
+	var req dto.AccrualQuoteInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	quote, err := s.mileage.QuotePublicAccrual(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, quote)
+}
+
+func (s Controller) SubmitAccrualRequestOnBehalf(c lit.Context) error {
+	var req dto.AdminAccrualRequestInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.SubmitAccrualRequestOnBehalf(c, req); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "submit successfully"})
+}
+
+func (s Controller) VerifyBoardingPass(c lit.Context) error {
+	var req dto.VerifyBoardingPassInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.VerifyBoardingPass(c, req.ID); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "verify successfully"})
+}
+
 func (s Controller) ApproveRequest(c lit.Context) error {
 	var req dto.ApproveRequestInput
 	if err := c.Bind(&req); err != nil {
@@ -105,13 +242,120 @@ func (s Controller) ApproveRequest(c lit.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{"message": "approve successfully"})
 }
 
+func (s Controller) CancelRequest(c lit.Context) error {
+	var req dto.CancelRequestInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.CancelAccrualRequest(c, req.ID, req.Reason); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "cancel successfully"})
+}
+
+func (s Controller) ResubmitRequest(c lit.Context) error {
+	var req dto.ResubmitAccrualRequestInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.ResubmitAccrualRequest(c, req); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "resubmit successfully"})
+}
+
+func (s Controller) RequestInfo(c lit.Context) error {
+	var req dto.RequestInfoInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.RequestAccrualInfo(c, req.ID, req.Message); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "request info successfully"})
+}
+
+func (s Controller) RetryDeposits(c lit.Context) error {
+	var req dto.RetryDepositsInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.RetryDeposits(c, req.ID); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "retry deposits successfully"})
+}
+
+func (s Controller) ReverseRequest(c lit.Context) error {
+	var req dto.ReverseRequestInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.ReverseAccrualRequest(c, req.ID, req.Reason, req.Force); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "reverse successfully"})
+}
+
+// BatchReview serves POST /admin/accrual-requests:batch, the action is matched as a path parameter
+func (s Controller) BatchReview(c lit.Context) error {
+	if c.Param("action") != ":batch" {
+		return lit.HTTPError{Status: http.StatusNotFound, Code: "not_found", Desc: "route not found"}
+	}
+
+	var req dto.BatchReviewInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data": s.mileage.BatchReviewAccrualRequests(c, req),
+	})
+}
+
 func (s Controller) RejectRequest(c lit.Context) error {
 	var req dto.RejectedRequestInput
 	if err := c.Bind(&req); err != nil {
 		return err
 	}
 
-	if err := s.mileage.RejectAccrualRequest(c, req.ID, req.RejectedReason); err != nil {
+	if err := s.mileage.RejectAccrualRequest(c, req.ID, req.Rejection); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "reject successfully"})
+}
+
+func (s Controller) ApproveSegment(c lit.Context) error {
+	var req dto.ApproveSegmentInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.ApproveAccrualSegment(c, req.ID, req.SegmentID); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "approve successfully"})
+}
+
+func (s Controller) RejectSegment(c lit.Context) error {
+	var req dto.RejectSegmentInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.RejectAccrualSegment(c, req.ID, req.SegmentID, req.Rejection); err != nil {
 		return convertErr(err)
 	}
 
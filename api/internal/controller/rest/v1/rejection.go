@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) GetRejectionReasons(c lit.Context) error {
+	data := s.mileage.GetRejectionReasons(c)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data": data,
+	})
+}
+
+func (s Controller) GetRejectionReport(c lit.Context) error {
+	var req dto.RejectionReportFilter
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.GetRejectionReport(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data": data,
+	})
+}
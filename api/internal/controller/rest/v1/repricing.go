@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) RepriceAccrualRequests(c lit.Context) error {
+	var req dto.RepriceInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.RepriceAccrualRequests(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s Controller) GetRepricings(c lit.Context) error {
+	var req dto.RepricingFilter
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, total, err := s.mileage.GetRepricings(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": total,
+	})
+}
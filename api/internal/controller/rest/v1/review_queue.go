@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit"
+)
+
+func (s Controller) GetReviewQueue(c lit.Context) error {
+	var req dto.ReviewQueueFilter
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, total, err := s.mileage.GetReviewQueue(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"total": total,
+	})
+}
+
+func (s Controller) LockRequest(c lit.Context) error {
+	var req dto.ReviewLockInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.LockAccrualRequest(c, req.ID)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s Controller) UnlockRequest(c lit.Context) error {
+	var req dto.ReviewLockInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.mileage.UnlockAccrualRequest(c, req.ID); err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "unlock successfully"})
+}
+
+func (s Controller) GetReviewSLAReport(c lit.Context) error {
+	var req dto.ReviewSLAFilter
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	data, err := s.mileage.GetReviewSLAReport(c, req)
+	if err != nil {
+		return convertErr(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data": data,
+	})
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccrualRateChart struct {
+	ID               uuid.UUID          `json:"id,string" gorm:"primaryKey"`
+	Name             string             `json:"name" gorm:"type:text;not null"`
+	Carrier          string             `json:"carrier" gorm:"type:text;not null"`  // Marketing carrier
+	OperatingCarrier *string            `json:"operating_carrier" gorm:"type:text"` // NULL means any operating carrier
+	ValidFrom        time.Time          `json:"valid_from" gorm:"type:date;not null"`
+	ValidTo          *time.Time         `json:"valid_to" gorm:"type:date"`                            // Inclusive, NULL means open-ended
+	EarningMode      string             `json:"earning_mode" gorm:"type:text;not null"`               // 'distance', 'revenue'
+	Rates            []AccrualRate      `json:"rates,omitempty" gorm:"foreignKey:ChartID"`            // Distance mode, only listed booking classes are eligible
+	TierBonusUplifts map[string]float64 `json:"tier_bonus_uplifts" gorm:"type:jsonb;serializer:json"` // Distance mode, member tier => extra share of bonus miles
+	ProgramCurrency  *string            `json:"program_currency" gorm:"type:text"`                    // Revenue mode, currency fares are converted into
+	RevenueRates     map[string]float64 `json:"revenue_rates" gorm:"type:jsonb;serializer:json"`      // Revenue mode, member tier => miles per program currency unit
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AccrualRateChart) TableName() string {
+	return "accrual_rate_charts"
+}
+
+type AccrualRate struct {
+	ID             uuid.UUID  `json:"id,string" gorm:"primaryKey"`
+	ChartID        uuid.UUID  `json:"chart_id,string"`
+	BookingClass   string     `json:"booking_class" gorm:"type:text;not null"`
+	QualifyingRate float64    `json:"qualifying_rate"`
+	BonusTiers     BonusTiers `json:"bonus_tiers" gorm:"type:jsonb;serializer:json"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AccrualRate) TableName() string {
+	return "accrual_rates"
+}
+
+// BonusTiers maps flight distance thresholds to bonus accrual rates
+type BonusTiers struct {
+	Miles  []float64 `json:"miles"`
+	Values []float64 `json:"values"`
+}
+
+// At returns the bonus rate of the highest threshold exceeded by miles, falling back to the first tier
+func (b BonusTiers) At(miles float64) float64 {
+	if len(b.Values) == 0 {
+		return 0
+	}
+
+	var index int
+	for idx, m := range b.Miles {
+		if miles > m && idx < len(b.Values) {
+			index = idx
+		}
+	}
+	return b.Values[index]
+}
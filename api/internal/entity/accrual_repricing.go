@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+)
+
+// AccrualRepricing is the audit record of one segment whose miles changed when it was priced again
+type AccrualRepricing struct {
+	ID                    uuid.UUID   `json:"id" gorm:"primaryKey"`
+	RunID                 uuid.UUID   `json:"run_id"` // Segments re-priced by the same operation share a run
+	AccrualRequestID      uuid.UUID   `json:"accrual_request_id"`
+	SegmentID             uuid.UUID   `json:"segment_id" gorm:"column:accrual_request_segment_id"`
+	SegmentStatus         string      `json:"segment_status"`
+	OldAccrualRateChartID *uuid.UUID  `json:"old_accrual_rate_chart_id"`
+	NewAccrualRateChartID *uuid.UUID  `json:"new_accrual_rate_chart_id"`
+	OldQualifyingMiles    miles.Miles `json:"old_qualifying_miles"`
+	NewQualifyingMiles    miles.Miles `json:"new_qualifying_miles"`
+	OldBonusMiles         miles.Miles `json:"old_bonus_miles"`
+	NewBonusMiles         miles.Miles `json:"new_bonus_miles"`
+	OldTierBonusMiles     miles.Miles `json:"old_tier_bonus_miles"`
+	NewTierBonusMiles     miles.Miles `json:"new_tier_bonus_miles"`
+	CorrectionLedgerID    *uuid.UUID  `json:"correction_ledger_id" gorm:"column:miles_ledger_id"` // Only for segments that were already credited
+	Reason                string      `json:"reason"`
+	RepricedBy            string      `json:"repriced_by"`
+	CreatedAt             time.Time   `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AccrualRepricing) TableName() string {
+	return "accrual_repricings"
+}
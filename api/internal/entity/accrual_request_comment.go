@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccrualRequestComment is one message in the conversation on an accrual request
+type AccrualRequestComment struct {
+	ID               uuid.UUID `json:"id" gorm:"primaryKey"`
+	AccrualRequestID uuid.UUID `json:"accrual_request_id"`
+	AuthorID         string    `json:"author_id"`
+	AuthorRole       string    `json:"author_role"` // 'member', 'admin'
+	Body             string    `json:"body"`
+	Internal         bool      `json:"internal"`                                      // Only reviewers can see it
+	Attachments      []string  `json:"attachments" gorm:"type:jsonb;serializer:json"` // URLs of uploaded files
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AccrualRequestComment) TableName() string {
+	return "accrual_request_comments"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccrualRequestEvent records one status transition of an accrual request
+type AccrualRequestEvent struct {
+	ID               uuid.UUID `json:"id" gorm:"primaryKey"`
+	AccrualRequestID uuid.UUID `json:"accrual_request_id"`
+	FromStatus       string    `json:"from_status"` // Empty for the submission
+	ToStatus         string    `json:"to_status"`
+	Actor            string    `json:"actor"` // User ID of the member or admin who made the change
+	Reason           *string   `json:"reason"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AccrualRequestEvent) TableName() string {
+	return "accrual_request_events"
+}
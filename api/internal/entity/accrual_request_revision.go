@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccrualRequestRevision is one round trip of a request sent back to the member for more information
+type AccrualRequestRevision struct {
+	ID               uuid.UUID              `json:"id" gorm:"primaryKey"`
+	AccrualRequestID uuid.UUID              `json:"accrual_request_id"`
+	RevisionNo       int                    `json:"revision_no"`
+	InfoRequest      string                 `json:"info_request"` // What the reviewer asked for
+	RequestedBy      string                 `json:"requested_by"`
+	RequestedAt      time.Time              `json:"requested_at"`
+	MemberNote       *string                `json:"member_note"`
+	Changes          map[string]FieldChange `json:"changes" gorm:"type:jsonb;serializer:json"` // Field => value before and after the resubmission
+	CreatedAt        time.Time              `json:"created_at"`                                // When the member resubmitted
+}
+
+// FieldChange holds a field's value before and after a resubmission
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// TableName specifies the table name for GORM
+func (AccrualRequestRevision) TableName() string {
+	return "accrual_request_revisions"
+}
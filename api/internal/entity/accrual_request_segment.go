@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+)
+
+// AccrualRequestSegment is one flown coupon of an itinerary claim, priced and reviewed on its own
+type AccrualRequestSegment struct {
+	ID                    uuid.UUID   `json:"id,string" gorm:"primaryKey"`
+	AccrualRequestID      uuid.UUID   `json:"accrual_request_id,string"`
+	SegmentNo             int         `json:"segment_no"`
+	Status                string      `json:"status"`
+	TicketID              string      `json:"ticket_id"`
+	CouponNumber          int         `json:"coupon_number"` // 0 when the member did not provide it
+	Carrier               string      `json:"carrier"`
+	OperatingCarrier      *string     `json:"operating_carrier"`
+	BookingClass          string      `json:"booking_class"`
+	FromCode              string      `json:"from_code"`
+	ToCode                string      `json:"to_code"`
+	DepartureDate         time.Time   `json:"departure_date"`
+	DistanceMiles         int         `json:"distance_miles"`
+	DistanceSource        string      `json:"distance_source"` // 'published', 'computed'
+	EarningMode           string      `json:"earning_mode"`    // 'distance', 'revenue'
+	EligibleSpend         float64     `json:"eligible_spend"`  // Revenue mode, prorated fare in the program currency
+	ExchangeRate          float64     `json:"exchange_rate"`   // Revenue mode, fare currency => program currency
+	AccrualRateChartID    *uuid.UUID  `json:"accrual_rate_chart_id"`
+	QualifyingAccrualRate float64     `json:"qualifying_accrual_rate"`
+	QualifyingMiles       miles.Miles `json:"qualifying_miles"`
+	BonusAccrualRate      float64     `json:"bonus_accrual_rate"`
+	BonusMiles            miles.Miles `json:"bonus_miles"`
+	TierBonusRate         float64     `json:"tier_bonus_rate"`
+	TierBonusMiles        miles.Miles `json:"tier_bonus_miles"`
+	RejectedReason        *string     `json:"rejected_reason"` // In the caller's language when a reason code is set
+	RejectedReasonCode    *string     `json:"rejected_reason_code"`
+	RejectedNote          *string     `json:"rejected_note"`
+	CreatedAt             time.Time   `json:"created_at"`
+	UpdatedAt             time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AccrualRequestSegment) TableName() string {
+	return "accrual_request_segments"
+}
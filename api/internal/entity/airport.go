@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+type Airport struct {
+	Code      string    `json:"code" gorm:"primaryKey"` // IATA code
+	Name      string    `json:"name" gorm:"type:text;not null"`
+	City      string    `json:"city"`
+	Country   string    `json:"country"`  // ISO 3166-1 alpha-2
+	Timezone  string    `json:"timezone"` // IANA time zone
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Airport) TableName() string {
+	return "airports"
+}
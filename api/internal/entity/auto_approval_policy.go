@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+)
+
+// AutoApprovalPolicy decides which new claims are approved without a reviewer, there is a single policy
+type AutoApprovalPolicy struct {
+	ID                          int64       `json:"-" gorm:"primaryKey"`
+	Enabled                     bool        `json:"enabled"`                        // Kill switch, nothing is auto approved while off
+	RequireVerifiedBoardingPass bool        `json:"require_verified_boarding_pass"` // Claims wait for a reviewer to verify the boarding pass
+	RequirePublishedRoute       bool        `json:"require_published_route"`        // Distances computed from coordinates are left to a reviewer
+	MaxRiskScore                int         `json:"max_risk_score"`
+	MaxMiles                    miles.Miles `json:"max_miles"`                                      // Qualifying and bonus miles of the whole claim
+	MemberTiers                 []string    `json:"member_tiers" gorm:"type:jsonb;serializer:json"` // Empty means any tier
+	UpdatedBy                   *string     `json:"updated_by"`
+	UpdatedAt                   time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AutoApprovalPolicy) TableName() string {
+	return "auto_approval_policies"
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+)
+
+type BookingClass struct {
+	Code           string    `json:"code" gorm:"primaryKey"`
+	Cabin          string    `json:"cabin"`            // 'business', 'premium_economy', 'economy'
+	FareFamily     string    `json:"fare_family"`      // 'business', 'premium_economy', 'flex', 'classic', 'lite', 'super_lite'
+	FareFamilyName string    `json:"fare_family_name"` // Display name, e.g. "Economy Flex"
+	Eligible       bool      `json:"eligible"`         // Whether the class earns miles at all
+	SortOrder      int       `json:"sort_order"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (BookingClass) TableName() string {
+	return "booking_classes"
+}
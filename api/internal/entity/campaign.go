@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+)
+
+type Campaign struct {
+	ID             uuid.UUID   `json:"id,string" gorm:"primaryKey"`
+	Name           string      `json:"name" gorm:"type:text;not null"`
+	Description    string      `json:"description" gorm:"type:text"`
+	Status         string      `json:"status" gorm:"type:text;not null"` // 'draft', 'scheduled', 'paused'
+	StartsAt       time.Time   `json:"starts_at"`
+	EndsAt         *time.Time  `json:"ends_at"`
+	FromCode       *string     `json:"from_code"` // NULL means any origin
+	ToCode         *string     `json:"to_code"`   // NULL means any destination
+	Bidirectional  bool        `json:"bidirectional"`
+	BookingClasses []string    `json:"booking_classes" gorm:"type:jsonb;serializer:json"` // Empty means any class
+	MemberTiers    []string    `json:"member_tiers" gorm:"type:jsonb;serializer:json"`    // Empty means any tier
+	DepartureFrom  *time.Time  `json:"departure_from" gorm:"type:date"`
+	DepartureTo    *time.Time  `json:"departure_to" gorm:"type:date"`
+	RequiresOptIn  bool        `json:"requires_opt_in"`
+	BonusRate      float64     `json:"bonus_rate"`  // Share of the qualifying miles, 1.0 doubles the miles
+	FixedMiles     miles.Miles `json:"fixed_miles"` // Flat bonus on top of the rate
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Campaign) TableName() string {
+	return "campaigns"
+}
+
+type CampaignOptIn struct {
+	CampaignID uuid.UUID `json:"campaign_id,string" gorm:"primaryKey"`
+	CustomerID uuid.UUID `json:"customer_id,string" gorm:"primaryKey"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (CampaignOptIn) TableName() string {
+	return "campaign_opt_ins"
+}
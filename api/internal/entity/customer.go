@@ -3,21 +3,22 @@ package entity
 import (
 	"time"
 
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
 	"github.com/google/uuid"
 )
 
 type Customer struct {
-	ID                   uuid.UUID `json:"id" gorm:"primaryKey"`
-	QualifyingMilesTotal float64   `json:"qualifying_miles_total"`
-	BonusMilesTotal      float64   `json:"bonus_miles_total"`
-	MemberTier           string    `json:"member_tier"`
-	Auth0UserID          string    `json:"auth0_user_id"`
-	Email                string    `json:"email"`
-	Phone                string    `json:"phone"`
-	FirstName            string    `json:"first_name"`
-	LastName             string    `json:"last_name"`
-	CreatedAt            time.Time `json:"created_at"`
-	UpdatedAt            time.Time `json:"updated_at"`
+	ID                   uuid.UUID   `json:"id" gorm:"primaryKey"`
+	QualifyingMilesTotal miles.Miles `json:"qualifying_miles_total"`
+	BonusMilesTotal      miles.Miles `json:"bonus_miles_total"`
+	MemberTier           string      `json:"member_tier"`
+	Auth0UserID          string      `json:"auth0_user_id"`
+	Email                string      `json:"email"`
+	Phone                string      `json:"phone"`
+	FirstName            string      `json:"first_name"`
+	LastName             string      `json:"last_name"`
+	CreatedAt            time.Time   `json:"created_at"`
+	UpdatedAt            time.Time   `json:"updated_at"`
 }
 
 // TableName specifies the table name for GORM
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+)
+
+// ExchangeRate converts one unit of FromCurrency into Rate units of ToCurrency from ValidFrom onwards
+type ExchangeRate struct {
+	ID           int64     `json:"id" gorm:"primaryKey"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	Rate         float64   `json:"rate"`
+	ValidFrom    time.Time `json:"valid_from" gorm:"type:date"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
@@ -3,33 +3,73 @@ package entity
 import (
 	"time"
 
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
 	"github.com/google/uuid"
 )
 
+// AccrualRequest is a claim for one itinerary. Route and rate fields mirror the first segment,
+// miles and distance are the totals of all segments.
 type AccrualRequest struct {
-	ID                    uuid.UUID  `json:"id,string" gorm:"primaryKey"`
-	CustomerID            uuid.UUID  `json:"customer_id,string"`
-	Status                string     `json:"status"`
-	TicketID              string     `json:"ticket_id"`
-	PNR                   string     `json:"pnr"`
-	Carrier               string     `json:"carrier"`
-	BookingClass          string     `json:"booking_class"`
-	FromCode              string     `json:"from_code"`
-	ToCode                string     `json:"to_code"`
-	DepartureDate         time.Time  `json:"departure_date"`
-	TicketImageURL        string     `json:"ticket_image_url"`
-	BoardingPassImageURL  string     `json:"boarding_pass_image_url"`
-	DistanceMiles         int        `json:"distance_miles"`
-	QualifyingAccrualRate float64    `json:"qualifying_accrual_rate"`
-	QualifyingMiles       float64    `json:"qualifying_miles"`
-	BonusAccrualRate      float64    `json:"bonus_accrual_rate"`
-	BonusMiles            float64    `json:"bonus_miles"`
-	ReviewerID            *string    `json:"reviewer_id"`
-	ReviewedAt            *time.Time `json:"reviewed_at"`
-	RejectedReason        *string    `json:"rejected_reason"`
-	CreatedAt             time.Time  `json:"created_at"`
-	UpdatedAt             time.Time  `json:"updated_at"`
-	Customer              *Customer  `json:"customer,omitempty"`
+	ID                     uuid.UUID   `json:"id,string" gorm:"primaryKey"`
+	CustomerID             uuid.UUID   `json:"customer_id,string"`
+	Status                 string      `json:"status"`
+	TicketID               string      `json:"ticket_id"`
+	PNR                    string      `json:"pnr"`
+	Carrier                string      `json:"carrier"`
+	OperatingCarrier       *string     `json:"operating_carrier"`
+	BookingClass           string      `json:"booking_class"`
+	FromCode               string      `json:"from_code"`
+	ToCode                 string      `json:"to_code"`
+	DepartureDate          time.Time   `json:"departure_date"`
+	TicketImageURL         string      `json:"ticket_image_url"`
+	BoardingPassImageURL   string      `json:"boarding_pass_image_url"`
+	BoardingPassVerifiedBy *string     `json:"boarding_pass_verified_by"` // Reviewer who checked the image against the claim
+	BoardingPassVerifiedAt *time.Time  `json:"boarding_pass_verified_at"`
+	DistanceMiles          int         `json:"distance_miles"`
+	DistanceSource         string      `json:"distance_source"` // 'published', 'computed'
+	EarningMode            string      `json:"earning_mode"`    // 'distance', 'revenue'
+	BaseFare               float64     `json:"base_fare"`
+	Surcharges             float64     `json:"surcharges"` // Carrier imposed surcharges (YQ/YR), taxes are excluded
+	FareCurrency           *string     `json:"fare_currency"`
+	AccrualRateChartID     *uuid.UUID  `json:"accrual_rate_chart_id"`
+	QualifyingAccrualRate  float64     `json:"qualifying_accrual_rate"`
+	QualifyingMiles        miles.Miles `json:"qualifying_miles"`
+	BonusAccrualRate       float64     `json:"bonus_accrual_rate"`
+	BonusMiles             miles.Miles `json:"bonus_miles"`
+	MemberTier             string      `json:"member_tier"`
+	TierBonusRate          float64     `json:"tier_bonus_rate"`
+	TierBonusMiles         miles.Miles `json:"tier_bonus_miles"`
+	ReviewerID             *string     `json:"reviewer_id"`
+	FirstApproverID        *string     `json:"first_approver_id"` // Set when the claim needed a second admin
+	FirstApprovedAt        *time.Time  `json:"first_approved_at"`
+	SecondApproverID       *string     `json:"second_approver_id"`
+	SecondApprovedAt       *time.Time  `json:"second_approved_at"`
+	ReviewedAt             *time.Time  `json:"reviewed_at"`
+	RejectedReason         *string     `json:"rejected_reason"`      // In the caller's language when a reason code is set
+	RejectedReasonCode     *string     `json:"rejected_reason_code"` // One of constants.RejectionReasons
+	RejectedNote           *string     `json:"rejected_note"`        // Reviewer's note to the member, shown as written
+	LateClaimReason        *string     `json:"late_claim_reason"`    // Set when an admin accepted a claim past the claim window
+	LateClaimApprovedBy    *string     `json:"late_claim_approved_by"`
+	InfoRequest            *string     `json:"info_request"` // Set while the request waits for the member to answer a reviewer
+	AssignedTo             *string     `json:"assigned_to"`  // Reviewer who last claimed the request
+	RiskScore              int         `json:"risk_score"`   // 0 to 100, the sum of the fraud rules the claim triggered
+	RiskHits               []RiskHit   `json:"risk_hits" gorm:"type:jsonb;serializer:json"`
+	LockedUntil            *time.Time  `json:"locked_until"`  // Other reviewers cannot decide on the request before then
+	AutoApproved           bool        `json:"auto_approved"` // Approved by the auto approval policy rather than a reviewer
+	CreatedAt              time.Time   `json:"created_at"`
+	UpdatedAt              time.Time   `json:"updated_at"`
+	Customer               *Customer   `json:"customer,omitempty"`
+
+	Segments  []AccrualRequestSegment  `json:"segments" gorm:"foreignKey:AccrualRequestID"`
+	Events    []AccrualRequestEvent    `json:"events,omitempty" gorm:"foreignKey:AccrualRequestID"`
+	Revisions []AccrualRequestRevision `json:"revisions" gorm:"foreignKey:AccrualRequestID"`
+}
+
+// RiskHit is a fraud rule a claim triggered
+type RiskHit struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
 }
 
 // TableName specifies the table name for GORM
@@ -3,21 +3,27 @@ package entity
 import (
 	"time"
 
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
 	"github.com/google/uuid"
 )
 
 type MilesLedger struct {
-	ID                   uuid.UUID  `json:"id,string" gorm:"primaryKey"`
-	CustomerID           uuid.UUID  `json:"customer_id,string"`
-	QualifyingMilesDelta float64    `json:"qualifying_miles_delta"`
-	BonusMilesDelta      float64    `json:"bonus_miles_delta"`
-	AccrualRequestID     *uuid.UUID `json:"accrual_request_id"`
-	Kind                 string     `json:"kind" gorm:"type:text;not null"` // 'accrual','adjustment','expire','correction'
-	EarningMonth         time.Time  `json:"earning_month" gorm:"type:date;not null"`
-	ExpiresAt            *time.Time `json:"expires_at" gorm:"type:date"`
-	Note                 string     `json:"note" gorm:"type:text"`
-	CreatedAt            time.Time  `json:"created_at"`
-	UpdatedAt            time.Time  `json:"updated_at"`
+	ID                   uuid.UUID   `json:"id,string" gorm:"primaryKey"`
+	CustomerID           uuid.UUID   `json:"customer_id,string"`
+	QualifyingMilesDelta miles.Miles `json:"qualifying_miles_delta"`
+	BonusMilesDelta      miles.Miles `json:"bonus_miles_delta"`
+	AccrualRequestID     *uuid.UUID  `json:"accrual_request_id"`
+	SegmentID            *uuid.UUID  `json:"segment_id" gorm:"column:accrual_request_segment_id"`
+	CampaignID           *uuid.UUID  `json:"campaign_id"`
+	Kind                 string      `json:"kind" gorm:"type:text;not null"` // 'accrual','promotion','adjustment','expire','correction'
+	EarningMonth         time.Time   `json:"earning_month" gorm:"type:date;not null"`
+	ExpiresAt            *time.Time  `json:"expires_at" gorm:"type:date"`
+	Note                 string      `json:"note" gorm:"type:text"`
+	ReferenceID          *string     `json:"reference_id"`  // Key of the points account movement, posting it again cannot move the miles twice
+	PostedAt             *time.Time  `json:"posted_at"`     // Nil until the points account has moved
+	PostingError         *string     `json:"posting_error"` // Why the last attempt to move the points account failed
+	CreatedAt            time.Time   `json:"created_at"`
+	UpdatedAt            time.Time   `json:"updated_at"`
 }
 
 // TableName specifies the table name for GORM
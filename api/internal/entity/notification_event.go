@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationEvent is a message waiting in the outbox to be delivered by the notification sender
+type NotificationEvent struct {
+	ID               uuid.UUID      `json:"id" gorm:"primaryKey"`
+	Kind             string         `json:"kind"`
+	Audience         string         `json:"audience"`     // 'member', 'reviewers'
+	RecipientID      *string        `json:"recipient_id"` // User ID, empty to reach every reviewer
+	AccrualRequestID *uuid.UUID     `json:"accrual_request_id"`
+	Payload          map[string]any `json:"payload" gorm:"type:jsonb;serializer:json"`
+	CreatedAt        time.Time      `json:"created_at"`
+	PublishedAt      *time.Time     `json:"published_at"` // Set once delivered
+}
+
+// TableName specifies the table name for GORM
+func (NotificationEvent) TableName() string {
+	return "notification_events"
+}
@@ -18,13 +18,16 @@ type Client interface {
 	CreateUser(ctx context.Context, request dto.SessionMCreateUserRequest) (dto.SessionMUserProfile, error)
 
 	DepositPoints(ctx context.Context, request dto.SessionMDepositPointsRequest) (dto.SessionMDepositPointsResponse, error)
+
+	WithdrawPoints(ctx context.Context, request dto.SessionMWithdrawPointsRequest) (dto.SessionMDepositPointsResponse, error)
 }
 
 type client struct {
-	getUserClient      HTTPClient
-	createUserClient   HTTPClient
-	depositPointClient HTTPClient
-	cfg                config.SessionMConfig
+	getUserClient       HTTPClient
+	createUserClient    HTTPClient
+	depositPointClient  HTTPClient
+	withdrawPointClient HTTPClient
+	cfg                 config.SessionMConfig
 }
 
 func New(cfg config.SessionMConfig) (Client, error) {
@@ -45,11 +48,17 @@ func New(cfg config.SessionMConfig) (Client, error) {
 		return nil, err
 	}
 
+	withdrawPointClient, err := withdrawPointsClientFunc(clientPool, cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &client{
-		getUserClient:      getUserClient,
-		createUserClient:   createUserClient,
-		depositPointClient: depositPointClient,
-		cfg:                cfg,
+		getUserClient:       getUserClient,
+		createUserClient:    createUserClient,
+		depositPointClient:  depositPointClient,
+		withdrawPointClient: withdrawPointClient,
+		cfg:                 cfg,
 	}, nil
 }
 
@@ -125,6 +134,31 @@ func (c client) DepositPoints(ctx context.Context, request dto.SessionMDepositPo
 	return response, nil
 }
 
+func (c client) WithdrawPoints(ctx context.Context, request dto.SessionMWithdrawPointsRequest) (dto.SessionMDepositPointsResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return dto.SessionMDepositPointsResponse{}, err
+	}
+
+	resp, err := c.withdrawPointClient.Send(ctx, httpclient.Payload{
+		Body: body,
+	})
+	if err != nil {
+		return dto.SessionMDepositPointsResponse{}, err
+	}
+
+	if resp.Status != http.StatusOK && resp.Status != http.StatusCreated {
+		return dto.SessionMDepositPointsResponse{}, fmt.Errorf("[sessionm] failed to withdraw points: %d", resp.Status)
+	}
+
+	var response dto.SessionMDepositPointsResponse
+	if err := json.Unmarshal(resp.Body, &response); err != nil {
+		return dto.SessionMDepositPointsResponse{}, err
+	}
+
+	return response, nil
+}
+
 type Error struct {
 	Status string `json:"status"`
 	Errors struct {
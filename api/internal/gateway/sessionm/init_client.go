@@ -123,3 +123,33 @@ func depositPointsClientFunc(
 		Password: cfg.IncentivesSecret,
 	}, nil
 }
+
+func withdrawPointsClientFunc(
+	clientPool *httpclient.SharedCustomPool,
+	cfg config.SessionMConfig,
+) (HTTPClient, error) {
+	cl, err := httpclient.NewUnauthenticated(
+		httpclient.Config{
+			ServiceName: serviceName,
+			URL:         fmt.Sprintf("%s/incentives/api/2.0/user_points/withdraw", cfg.IncentivesAPIURL),
+			Method:      http.MethodPost,
+		},
+		clientPool,
+		httpclient.OverrideTimeoutAndRetryOption(
+			5,
+			time.Minute,
+			15*time.Minute,
+			true,
+			[]int{http.StatusInternalServerError, http.StatusBadGateway},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return basicAuthHTTPClient{
+		Client:   cl,
+		Username: cfg.IncentivesAppKey,
+		Password: cfg.IncentivesSecret,
+	}, nil
+}
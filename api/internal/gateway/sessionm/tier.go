@@ -0,0 +1,33 @@
+package sessionm
+
+import (
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+)
+
+// MemberTier resolves the member tier of a SessionM profile within the given tier system
+func MemberTier(profile dto.SessionMUserProfile, tierSystemID string) string {
+	tier := constants.MemberTierRegister
+	for _, tierLevel := range profile.TierDetails.TierLevels {
+		if tierLevel.TierSystemID == tierSystemID {
+			tier = convertMemberTier(tierLevel.TierOverview.Name)
+		}
+	}
+	return tier
+}
+
+func convertMemberTier(tier string) string {
+	tierNameMap := map[string]string{
+		"[Team-1] Silver":        constants.MemberTierSilver,
+		"[Team-1] Titan":         constants.MemberTierTitan,
+		"[Team-1] Gold":          constants.MemberTierGold,
+		"[Team-1] Platinum":      constants.MemberTierPlatinum,
+		"[Team-1] Million Miler": constants.MemberTierMillionMiler,
+	}
+
+	if tierName, exists := tierNameMap[tier]; exists {
+		return tierName
+	}
+
+	return constants.MemberTierRegister
+}
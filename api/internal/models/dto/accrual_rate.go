@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+)
+
+type AccrualRateChartInput struct {
+	ID               string             `uri:"id"`
+	Name             string             `json:"name" binding:"required,min=1"`
+	Carrier          string             `json:"carrier" binding:"required,min=2,max=3"`
+	OperatingCarrier string             `json:"operating_carrier" binding:"omitempty,min=2,max=3"`
+	ValidFrom        time.Time          `json:"valid_from" binding:"required"`
+	ValidTo          *time.Time         `json:"valid_to"`
+	EarningMode      string             `json:"earning_mode" binding:"omitempty,oneof=distance revenue"` // Defaults to distance
+	Rates            []AccrualRateInput `json:"rates" binding:"omitempty,dive"`
+	TierBonusUplifts map[string]float64 `json:"tier_bonus_uplifts" binding:"omitempty,dive,keys,oneof=register silver titan gold platinum million_miler,endkeys,gte=0"`
+	ProgramCurrency  string             `json:"program_currency" binding:"omitempty,len=3"`
+	RevenueRates     map[string]float64 `json:"revenue_rates" binding:"omitempty,dive,keys,oneof=register silver titan gold platinum million_miler,endkeys,gte=0"`
+}
+
+type AccrualRateInput struct {
+	BookingClass   string    `json:"booking_class" binding:"required,min=1,max=1"`
+	QualifyingRate float64   `json:"qualifying_rate" binding:"gte=0"`
+	BonusMiles     []float64 `json:"bonus_miles" binding:"required,min=1"`
+	BonusValues    []float64 `json:"bonus_values" binding:"required,min=1"`
+}
+
+type AccrualRateChartIDInput struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type AccrualRateChartFilter struct {
+	Carrier string `form:"carrier" json:"carrier"`
+	Page    int    `form:"page" json:"page"`
+	Size    int    `form:"size" json:"size"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+)
+
+type GetAccrualRequestInput struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// AccrualRequestDetail is a request with its history and what it credited
+type AccrualRequestDetail struct {
+	entity.AccrualRequest
+	Customer       *CustomerSummary               `json:"customer"`
+	Reviewer       *ReviewerIdentity              `json:"reviewer"`
+	FirstApprover  *ReviewerIdentity              `json:"first_approver"`
+	SecondApprover *ReviewerIdentity              `json:"second_approver"`
+	Ledgers        []entity.MilesLedger           `json:"ledgers"`
+	PointsProvider string                         `json:"points_provider"` // 'local', 'sessionm'
+	Deposits       []SegmentDeposit               `json:"deposits"`
+	Comments       []entity.AccrualRequestComment `json:"comments"` // Internal comments for admins only
+}
+
+type CustomerSummary struct {
+	ID         uuid.UUID `json:"id"`
+	Email      string    `json:"email"`
+	FirstName  string    `json:"first_name"`
+	LastName   string    `json:"last_name"`
+	MemberTier string    `json:"member_tier"`
+}
+
+type ReviewerIdentity struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email,omitempty"` // Admins only
+	System bool   `json:"system"`          // Decided by the auto approval policy
+}
+
+// SegmentDeposit is the outcome of crediting a segment's miles to the points provider
+type SegmentDeposit struct {
+	SegmentID       uuid.UUID   `json:"segment_id"`
+	Status          string      `json:"status"` // 'pending', 'posting', 'deposited', 'failed', 'reversed', 'not_applicable', 'unknown'
+	QualifyingMiles miles.Miles `json:"qualifying_miles"`
+	BonusMiles      miles.Miles `json:"bonus_miles"`
+	ReferenceID     *string     `json:"reference_id"` // Sent to the points provider with the deposit
+	PostedAt        *time.Time  `json:"posted_at"`    // When the points provider accepted the deposit
+	Error           *string     `json:"error"`        // What the points provider answered when it failed
+}
@@ -0,0 +1,6 @@
+package dto
+
+type AirportFilter struct {
+	Query string `form:"query" json:"query" binding:"required,min=1"`
+	Size  int    `form:"size" json:"size" binding:"omitempty,min=1,max=20"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+)
+
+type AutoApprovalPolicyInput struct {
+	Enabled                     bool        `json:"enabled"`
+	RequireVerifiedBoardingPass bool        `json:"require_verified_boarding_pass"`
+	RequirePublishedRoute       bool        `json:"require_published_route"`
+	MaxRiskScore                int         `json:"max_risk_score" binding:"min=0,max=100"`
+	MaxMiles                    miles.Miles `json:"max_miles" binding:"required,min=1"`
+	MemberTiers                 []string    `json:"member_tiers" binding:"omitempty,dive,oneof=register silver titan gold platinum million_miler"` // Empty means any tier
+}
+
+// AutoApprovalReportFilter selects the auto approvals to report on, it defaults to the last 30 days
+type AutoApprovalReportFilter struct {
+	From time.Time `form:"from" json:"from"`
+	To   time.Time `form:"to" json:"to"`
+}
+
+type AutoApprovalReport struct {
+	From          time.Time   `json:"from"`
+	To            time.Time   `json:"to"`
+	Enabled       bool        `json:"enabled"`
+	Approved      int64       `json:"approved"`
+	Reversed      int64       `json:"reversed"` // Auto approved, then taken back by a reviewer
+	CreditedMiles miles.Miles `json:"credited_miles"`
+}
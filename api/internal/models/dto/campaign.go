@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+)
+
+type CampaignInput struct {
+	ID             string      `uri:"id"`
+	Name           string      `json:"name" binding:"required,min=1"`
+	Description    string      `json:"description"`
+	StartsAt       time.Time   `json:"starts_at" binding:"required"`
+	EndsAt         *time.Time  `json:"ends_at"`
+	FromCode       string      `json:"from_code" binding:"omitempty,min=3,max=3"`
+	ToCode         string      `json:"to_code" binding:"omitempty,min=3,max=3"`
+	Bidirectional  bool        `json:"bidirectional"`
+	BookingClasses []string    `json:"booking_classes" binding:"omitempty,dive,min=1,max=1"`
+	MemberTiers    []string    `json:"member_tiers" binding:"omitempty,dive,oneof=register silver titan gold platinum million_miler"`
+	DepartureFrom  *time.Time  `json:"departure_from"`
+	DepartureTo    *time.Time  `json:"departure_to"`
+	RequiresOptIn  bool        `json:"requires_opt_in"`
+	BonusRate      float64     `json:"bonus_rate" binding:"gte=0"`
+	FixedMiles     miles.Miles `json:"fixed_miles" binding:"gte=0"`
+}
+
+type CampaignIDInput struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type CampaignFilter struct {
+	Status string `form:"status" json:"status"`
+	Page   int    `form:"page" json:"page"`
+	Size   int    `form:"size" json:"size"`
+}
+
+type CampaignReport struct {
+	CampaignID uuid.UUID   `json:"campaign_id"`
+	OptIns     int64       `json:"opt_ins"`
+	Awards     int64       `json:"awards"`
+	Customers  int64       `json:"customers"`
+	BonusMiles miles.Miles `json:"bonus_miles"`
+}
+
+// CampaignAward is the bonus a campaign grants to one accrual request
+type CampaignAward struct {
+	CampaignID   uuid.UUID   `json:"campaign_id"`
+	CampaignName string      `json:"campaign_name"`
+	BonusMiles   miles.Miles `json:"bonus_miles"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+// CommentInput is a comment a member posts on their own request
+type CommentInput struct {
+	ID          string   `uri:"id" binding:"required,uuid"`
+	Body        string   `json:"body" binding:"required,min=1,max=2000"`
+	Attachments []string `json:"attachments" binding:"omitempty,max=5,dive,url"`
+}
+
+// AdminCommentInput is a comment a reviewer posts, internal comments are not shown to the member
+type AdminCommentInput struct {
+	CommentInput
+	Internal bool `json:"internal"`
+}
+
+type GetCommentsInput struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
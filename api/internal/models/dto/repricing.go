@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+)
+
+// RepriceInput selects the requests to price again, at least one filter is required
+type RepriceInput struct {
+	RequestIDs         []string   `json:"request_ids" binding:"omitempty,dive,uuid"`
+	AccrualRateChartID string     `json:"accrual_rate_chart_id" binding:"omitempty,uuid"`
+	Carrier            string     `json:"carrier" binding:"omitempty,min=2,max=3"`
+	DepartureFrom      *time.Time `json:"departure_from"`
+	DepartureTo        *time.Time `json:"departure_to"`
+	IncludeApproved    bool       `json:"include_approved"` // Also correct segments that were already credited
+	DryRun             bool       `json:"dry_run"`
+	Reason             string     `json:"reason"` // Required unless dry run
+}
+
+type RepricingResult struct {
+	RunID    *uuid.UUID      `json:"run_id"` // Empty for a dry run
+	DryRun   bool            `json:"dry_run"`
+	Requests int             `json:"requests"`
+	Changed  int             `json:"changed"`
+	Failed   int             `json:"failed"` // Requests that could not be saved or credited, listed with their error
+	Items    []RepricingItem `json:"items"`
+}
+
+// RepricingItem is the diff of one segment, or the reason its request could not be priced, saved or credited
+type RepricingItem struct {
+	AccrualRequestID   uuid.UUID   `json:"accrual_request_id"`
+	SegmentID          uuid.UUID   `json:"segment_id"`
+	SegmentStatus      string      `json:"segment_status"`
+	FromCode           string      `json:"from_code"`
+	ToCode             string      `json:"to_code"`
+	OldQualifyingMiles miles.Miles `json:"old_qualifying_miles"`
+	NewQualifyingMiles miles.Miles `json:"new_qualifying_miles"`
+	OldBonusMiles      miles.Miles `json:"old_bonus_miles"`
+	NewBonusMiles      miles.Miles `json:"new_bonus_miles"`
+	OldTierBonusMiles  miles.Miles `json:"old_tier_bonus_miles"`
+	NewTierBonusMiles  miles.Miles `json:"new_tier_bonus_miles"`
+	Correction         bool        `json:"correction"`
+	Error              string      `json:"error,omitempty"`
+}
+
+type RepricingFilter struct {
+	RunID            string `form:"run_id" json:"run_id"`
+	AccrualRequestID string `form:"accrual_request_id" json:"accrual_request_id"`
+	Page             int    `form:"page" json:"page"`
+	Size             int    `form:"size" json:"size"`
+}
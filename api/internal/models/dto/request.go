@@ -2,33 +2,171 @@ package dto
 
 import (
 	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
 )
 
+// AccrualRequestInput is a claim for one itinerary. The flat flight fields describe a single
+// segment claim and are ignored when Segments is given.
 type AccrualRequestInput struct {
-	TicketID             string    `json:"ticket_id" binding:"required,min=1"`
-	PNR                  string    `json:"pnr" binding:"required,min=1"`
-	Carrier              string    `json:"carrier" binding:"required,min=1"`
-	BookingClass         string    `json:"booking_class" binding:"required,min=1,max=1"`
-	FromCode             string    `json:"from_code" binding:"required,min=3,max=3"`
-	ToCode               string    `json:"to_code" binding:"required,min=3,max=3"`
-	DepartureDate        time.Time `json:"departure_date" binding:"required"`
-	TicketImageURL       string    `json:"ticket_image_url" binding:"omitempty,url"`
-	BoardingPassImageURL string    `json:"boarding_pass_image_url" binding:"omitempty,url"`
+	TicketID             string                `json:"ticket_id" binding:"required,min=1"`
+	PNR                  string                `json:"pnr" binding:"required,min=1"`
+	CouponNumber         int                   `json:"coupon_number" binding:"omitempty,min=1"`
+	Carrier              string                `json:"carrier" binding:"required_without=Segments,omitempty,min=1"`
+	OperatingCarrier     string                `json:"operating_carrier" binding:"omitempty,min=2,max=3"`
+	BookingClass         string                `json:"booking_class" binding:"required_without=Segments,omitempty,min=1,max=1"`
+	FromCode             string                `json:"from_code" binding:"required_without=Segments,omitempty,min=3,max=3"`
+	ToCode               string                `json:"to_code" binding:"required_without=Segments,omitempty,min=3,max=3"`
+	DepartureDate        time.Time             `json:"departure_date" binding:"required_without=Segments"`
+	Segments             []AccrualSegmentInput `json:"segments" binding:"omitempty,min=1,max=8,dive"`
+	BaseFare             float64               `json:"base_fare" binding:"omitempty,gte=0"` // Needed when the carrier earns on revenue
+	Surcharges           float64               `json:"surcharges" binding:"omitempty,gte=0"`
+	FareCurrency         string                `json:"fare_currency" binding:"required_with=BaseFare,omitempty,len=3"`
+	TicketImageURL       string                `json:"ticket_image_url" binding:"omitempty,url"`
+	BoardingPassImageURL string                `json:"boarding_pass_image_url" binding:"omitempty,url"`
+}
+
+// AdminAccrualRequestInput is a claim an admin files for a customer
+type AdminAccrualRequestInput struct {
+	AccrualRequestInput
+	CustomerID      string `json:"customer_id" binding:"required,uuid"`
+	LateClaimReason string `json:"late_claim_reason"` // Accepts a claim older than the claim window
+}
+
+type AccrualSegmentInput struct {
+	TicketID         string    `json:"ticket_id" binding:"omitempty,min=1"` // Defaults to the claim's ticket
+	CouponNumber     int       `json:"coupon_number" binding:"omitempty,min=1"`
+	Carrier          string    `json:"carrier" binding:"required,min=1"`
+	OperatingCarrier string    `json:"operating_carrier" binding:"omitempty,min=2,max=3"`
+	BookingClass     string    `json:"booking_class" binding:"required,min=1,max=1"`
+	FromCode         string    `json:"from_code" binding:"required,min=3,max=3"`
+	ToCode           string    `json:"to_code" binding:"required,min=3,max=3"`
+	DepartureDate    time.Time `json:"departure_date" binding:"required"`
 }
 
 type ApproveRequestInput struct {
 	ID string `uri:"id" binding:"required"`
 }
 
+type VerifyBoardingPassInput struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type RetryDepositsInput struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type CancelRequestInput struct {
+	ID     string  `uri:"id" binding:"required"`
+	Reason *string `json:"reason"`
+}
+
+type RequestInfoInput struct {
+	ID      string `uri:"id" binding:"required"`
+	Message string `json:"message" binding:"required,min=1"` // What the member should send or correct
+}
+
+// ResubmitAccrualRequestInput answers a reviewer's request for information, only the fields given are changed
+type ResubmitAccrualRequestInput struct {
+	ID                   string                 `uri:"id" binding:"required"`
+	PNR                  *string                `json:"pnr" binding:"omitempty,min=1"`
+	BaseFare             *float64               `json:"base_fare" binding:"omitempty,gte=0"`
+	Surcharges           *float64               `json:"surcharges" binding:"omitempty,gte=0"`
+	FareCurrency         *string                `json:"fare_currency" binding:"omitempty,len=3"`
+	TicketImageURL       *string                `json:"ticket_image_url" binding:"omitempty,url"`
+	BoardingPassImageURL *string                `json:"boarding_pass_image_url" binding:"omitempty,url"`
+	Segments             []ResubmitSegmentInput `json:"segments" binding:"omitempty,max=8,dive"`
+	Note                 *string                `json:"note"`
+}
+
+type ResubmitSegmentInput struct {
+	ID               string     `json:"id" binding:"required,uuid"`
+	CouponNumber     *int       `json:"coupon_number" binding:"omitempty,min=1"`
+	Carrier          *string    `json:"carrier" binding:"omitempty,min=1"`
+	OperatingCarrier *string    `json:"operating_carrier" binding:"omitempty,min=2,max=3"`
+	BookingClass     *string    `json:"booking_class" binding:"omitempty,min=1,max=1"`
+	FromCode         *string    `json:"from_code" binding:"omitempty,min=3,max=3"`
+	ToCode           *string    `json:"to_code" binding:"omitempty,min=3,max=3"`
+	DepartureDate    *time.Time `json:"departure_date"`
+}
+
+type ReverseRequestInput struct {
+	ID     string `uri:"id" binding:"required"`
+	Reason string `json:"reason" binding:"required,min=1"`
+	Force  bool   `json:"force"` // Reverse even when the member has spent the miles, leaving a negative balance
+}
+
+// BatchReviewInput approves or rejects many requests at once
+type BatchReviewInput struct {
+	Action         string   `json:"action" binding:"required,oneof=approve reject"`
+	IDs            []string `json:"ids" binding:"required,min=1,max=500,dive,uuid"`
+	ReasonCode     string   `json:"reason_code" binding:"omitempty,oneof=ticket_not_found name_mismatch class_ineligible outside_claim_window duplicate_claim flight_not_flown missing_documents other"`
+	Note           string   `json:"note" binding:"required_if=ReasonCode other,max=500"`
+	RejectedReason string   `json:"rejected_reason" binding:"required_if=Action reject ReasonCode ''"` // Deprecated, use reason_code
+}
+
+type BatchReviewResult struct {
+	ID     string `json:"id"`
+	Result string `json:"result"` // 'ok', 'invalid_status', 'not_found', 'locked', 'conflict', 'high_risk', 'error'
+	Error  string `json:"error,omitempty"`
+}
+
+// Rejection is why a reviewer turned a claim down, a code from the catalogue with an optional note for the member
+type Rejection struct {
+	ReasonCode     string `json:"reason_code" binding:"required_without=RejectedReason,omitempty,oneof=ticket_not_found name_mismatch class_ineligible outside_claim_window duplicate_claim flight_not_flown missing_documents other"`
+	Note           string `json:"note" binding:"required_if=ReasonCode other,max=500"`
+	RejectedReason string `json:"rejected_reason"` // Deprecated free text, read as the 'other' code with the text as its note
+}
+
+// Reason returns the reason code and note
+func (r Rejection) Reason() (string, string) {
+	if r.ReasonCode == "" {
+		return constants.RejectionReasonOther, r.RejectedReason
+	}
+	return r.ReasonCode, r.Note
+}
+
 type RejectedRequestInput struct {
-	ID             string `uri:"id" binding:"required"`
-	RejectedReason string `json:"rejected_reason" binding:"required,min=1"`
+	ID string `uri:"id" binding:"required"`
+	Rejection
+}
+
+type ApproveSegmentInput struct {
+	ID        string `uri:"id" binding:"required"`
+	SegmentID string `uri:"segment_id" binding:"required"`
+}
+
+type RejectSegmentInput struct {
+	ID        string `uri:"id" binding:"required"`
+	SegmentID string `uri:"segment_id" binding:"required"`
+	Rejection
+}
+
+// RejectionReasonReport counts the rejections with one reason code
+type RejectionReasonReport struct {
+	Code     string `json:"code"`
+	Label    string `json:"label"` // In the caller's language
+	Segments int64  `json:"segments"`
+	Requests int64  `json:"requests"` // Requests with at least one segment rejected for the reason
+}
+
+// RejectionReportFilter selects the decisions to report on, it defaults to the last 30 days
+type RejectionReportFilter struct {
+	From time.Time `form:"from" json:"from"`
+	To   time.Time `form:"to" json:"to"`
+}
+
+type RejectionReason struct {
+	Code  string `json:"code"`
+	Label string `json:"label"` // In the caller's language
 }
 
 type AccrualRequestFilter struct {
 	Keyword       string    `form:"keyword" json:"keyword"`
 	Status        string    `form:"status" json:"status"`
 	SubmittedDate time.Time `form:"submitted_date" json:"submitted_date"`
+	MinRiskScore  int       `form:"min_risk_score" json:"min_risk_score" binding:"omitempty,min=0,max=100"`
 	Page          int       `form:"page" json:"page"`
 	Size          int       `form:"size" json:"size"`
 }
@@ -38,3 +176,48 @@ type MileageLedgerFilter struct {
 	Page int       `form:"page" json:"page"`
 	Size int       `form:"size" json:"size"`
 }
+
+type AccrualQuoteInput struct {
+	Carrier          string                `json:"carrier" binding:"required_without=Segments,omitempty,min=1"`
+	OperatingCarrier string                `json:"operating_carrier" binding:"omitempty,min=2,max=3"`
+	BookingClass     string                `json:"booking_class" binding:"required_without=Segments,omitempty,min=1,max=1"`
+	FromCode         string                `json:"from_code" binding:"required_without=Segments,omitempty,min=3,max=3"`
+	ToCode           string                `json:"to_code" binding:"required_without=Segments,omitempty,min=3,max=3"`
+	DepartureDate    time.Time             `json:"departure_date" binding:"required_without=Segments"`
+	Segments         []AccrualSegmentInput `json:"segments" binding:"omitempty,min=1,max=8,dive"`
+	BaseFare         float64               `json:"base_fare" binding:"omitempty,gte=0"`
+	Surcharges       float64               `json:"surcharges" binding:"omitempty,gte=0"`
+	FareCurrency     string                `json:"fare_currency" binding:"required_with=BaseFare,omitempty,len=3"`
+	MemberTier       string                `json:"member_tier" binding:"omitempty,oneof=register silver titan gold platinum million_miler"` // Public quotes only
+}
+
+type AccrualQuote struct {
+	MemberTier      string                `json:"member_tier"`
+	EarningMode     string                `json:"earning_mode"`
+	DistanceMiles   int                   `json:"distance_miles"`
+	QualifyingMiles miles.Miles           `json:"qualifying_miles"`
+	BonusMiles      miles.Miles           `json:"bonus_miles"`
+	TierBonusMiles  miles.Miles           `json:"tier_bonus_miles"`
+	PromotionMiles  miles.Miles           `json:"promotion_miles"`
+	TotalMiles      miles.Miles           `json:"total_miles"`
+	Segments        []AccrualQuoteSegment `json:"segments"`
+}
+
+type AccrualQuoteSegment struct {
+	Carrier               string          `json:"carrier"`
+	BookingClass          string          `json:"booking_class"`
+	FromCode              string          `json:"from_code"`
+	ToCode                string          `json:"to_code"`
+	DepartureDate         time.Time       `json:"departure_date"`
+	DistanceMiles         int             `json:"distance_miles"`
+	DistanceSource        string          `json:"distance_source"`
+	EarningMode           string          `json:"earning_mode"`
+	EligibleSpend         float64         `json:"eligible_spend"`
+	QualifyingAccrualRate float64         `json:"qualifying_accrual_rate"`
+	QualifyingMiles       miles.Miles     `json:"qualifying_miles"`
+	BonusAccrualRate      float64         `json:"bonus_accrual_rate"`
+	BonusMiles            miles.Miles     `json:"bonus_miles"`
+	TierBonusRate         float64         `json:"tier_bonus_rate"`
+	TierBonusMiles        miles.Miles     `json:"tier_bonus_miles"`
+	Promotions            []CampaignAward `json:"promotions"`
+}
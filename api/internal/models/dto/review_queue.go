@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/google/uuid"
+)
+
+type ReviewQueueFilter struct {
+	Mine bool `form:"mine" json:"mine"` // Only the requests assigned to the signed in reviewer
+	Page int  `form:"page" json:"page"`
+	Size int  `form:"size" json:"size"`
+}
+
+// ReviewQueueItem is an open request with its SLA timer
+type ReviewQueueItem struct {
+	entity.AccrualRequest
+	WaitingHours float64   `json:"waiting_hours"` // Since submission
+	SLADueAt     time.Time `json:"sla_due_at"`
+	SLABreached  bool      `json:"sla_breached"`
+}
+
+type ReviewLockInput struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type ReviewLock struct {
+	AccrualRequestID uuid.UUID `json:"accrual_request_id"`
+	AssignedTo       string    `json:"assigned_to"`
+	LockedUntil      time.Time `json:"locked_until"`
+}
+
+// ReviewSLAFilter selects the decisions to report on, it defaults to the last 30 days
+type ReviewSLAFilter struct {
+	From time.Time `form:"from" json:"from"`
+	To   time.Time `form:"to" json:"to"`
+}
+
+type ReviewerSLAReport struct {
+	ReviewerID     string  `json:"reviewer_id"`
+	Reviewed       int64   `json:"reviewed"`
+	Breached       int64   `json:"breached"` // Decided later than the SLA
+	AvgReviewHours float64 `json:"avg_review_hours"`
+	Open           int64   `json:"open"`
+	OpenBreached   int64   `json:"open_breached"` // Assigned, still open and already past the SLA
+}
@@ -98,6 +98,16 @@ type SessionMDepositDetail struct {
 	Rank           int     `json:"rank"`
 }
 
+// SessionMWithdrawPointsRequest takes points back from a user, details have the same shape as deposits
+type SessionMWithdrawPointsRequest struct {
+	RetailerID             string                  `json:"retailer_id"`
+	UserID                 string                  `json:"user_id"`
+	WithdrawDetails        []SessionMDepositDetail `json:"withdraw_details"`
+	AllowPartialSuccess    bool                    `json:"allow_partial_success"`
+	DisableEventPublishing bool                    `json:"disable_event_publishing"`
+	Culture                string                  `json:"culture"`
+}
+
 // SessionMDepositPointsResponse là cấu trúc dữ liệu cho response từ API nạp điểm
 type SessionMDepositPointsResponse struct {
 	Success bool   `json:"success"`
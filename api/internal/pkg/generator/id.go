@@ -7,8 +7,18 @@ import (
 var (
 	CustomerID          UUIDGenerator
 	AccrualRequestID    UUIDGenerator
+	SegmentID           UUIDGenerator
 	MilesLedgerID       UUIDGenerator
 	MembershipHistoryID UUIDGenerator
+	AccrualRateChartID  UUIDGenerator
+	AccrualRateID       UUIDGenerator
+	CampaignID          UUIDGenerator
+	RepricingID         UUIDGenerator
+	RepricingRunID      UUIDGenerator
+	RequestEventID      UUIDGenerator
+	RevisionID          UUIDGenerator
+	CommentID           UUIDGenerator
+	NotificationEventID UUIDGenerator
 	// Create ID generator for each entity
 )
 
@@ -0,0 +1,23 @@
+package geo
+
+import (
+	"math"
+)
+
+// earthRadiusMiles is the mean Earth radius in statute miles
+const earthRadiusMiles = 3958.8
+
+// GreatCircleMiles returns the great-circle distance in statute miles between two points given in decimal degrees
+func GreatCircleMiles(fromLat, fromLon, toLat, toLon float64) float64 {
+	lat1, lat2 := toRadians(fromLat), toRadians(toLat)
+	dLat := lat2 - lat1
+	dLon := toRadians(toLon - fromLon)
+
+	// Haversine formula, stable for short distances
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMiles * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,70 @@
+package miles
+
+import (
+	"fmt"
+	"math"
+)
+
+// Miles is a whole number of miles. Fractional miles only exist while a rate is being applied
+// and are settled by a Rounding policy before they are stored, deposited or shown
+type Miles int64
+
+func (m Miles) Float64() float64 {
+	return float64(m)
+}
+
+// Rounding is the rule used to settle fractional miles, the zero value rounds half up
+type Rounding string
+
+const (
+	RoundHalfUp   Rounding = "half_up"   // 0.5 and above away from zero
+	RoundHalfEven Rounding = "half_even" // 0.5 to the nearest even mile
+	RoundDown     Rounding = "down"      // Fractions are dropped
+	RoundUp       Rounding = "up"        // Any fraction earns a full mile
+)
+
+// scale is the fixed-point precision, so 0.65 x 241 settles on 156.65 rather than 156.6499...
+const scale = 1_000_000
+
+func (r Rounding) Validate() error {
+	switch r {
+	case "", RoundHalfUp, RoundHalfEven, RoundDown, RoundUp:
+		return nil
+	default:
+		return fmt.Errorf("unknown miles rounding %q", string(r))
+	}
+}
+
+// Round settles v into whole miles
+func (r Rounding) Round(v float64) Miles {
+	fixed := int64(math.Round(v * scale))
+
+	sign := int64(1)
+	if fixed < 0 {
+		sign, fixed = -1, -fixed
+	}
+
+	whole, fraction := fixed/scale, fixed%scale
+	switch r {
+	case RoundDown:
+	case RoundUp:
+		if fraction > 0 {
+			whole++
+		}
+	case RoundHalfEven:
+		if fraction > scale/2 || (fraction == scale/2 && whole%2 == 1) {
+			whole++
+		}
+	default:
+		if fraction >= scale/2 {
+			whole++
+		}
+	}
+
+	return Miles(sign * whole)
+}
+
+// Apply multiplies base miles by rate and settles the result
+func (r Rounding) Apply(rate float64, base Miles) Miles {
+	return r.Round(rate * base.Float64())
+}
@@ -0,0 +1,76 @@
+package miles
+
+import "testing"
+
+func TestRounding_Round(t *testing.T) {
+	tcs := map[string]struct {
+		givenRounding Rounding
+		givenValue    float64
+		expMiles      Miles
+	}{
+		"half up below half":         {givenRounding: RoundHalfUp, givenValue: 156.49, expMiles: 156},
+		"half up at half":            {givenRounding: RoundHalfUp, givenValue: 156.5, expMiles: 157},
+		"half up negative at half":   {givenRounding: RoundHalfUp, givenValue: -156.5, expMiles: -157},
+		"zero value rounds half up":  {givenRounding: "", givenValue: 2.5, expMiles: 3},
+		"half even at half to even":  {givenRounding: RoundHalfEven, givenValue: 2.5, expMiles: 2},
+		"half even at half to odd":   {givenRounding: RoundHalfEven, givenValue: 3.5, expMiles: 4},
+		"half even above half":       {givenRounding: RoundHalfEven, givenValue: 2.51, expMiles: 3},
+		"down drops the fraction":    {givenRounding: RoundDown, givenValue: 156.99, expMiles: 156},
+		"down negative toward zero":  {givenRounding: RoundDown, givenValue: -156.99, expMiles: -156},
+		"up any fraction":            {givenRounding: RoundUp, givenValue: 156.01, expMiles: 157},
+		"up whole stays":             {givenRounding: RoundUp, givenValue: 156, expMiles: 156},
+		"float error is not rounded": {givenRounding: RoundUp, givenValue: 0.1 + 0.2 - 0.3, expMiles: 0},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.givenRounding.Round(tc.givenValue); got != tc.expMiles {
+				t.Fatalf("expected %d, got %d", tc.expMiles, got)
+			}
+		})
+	}
+}
+
+func TestRounding_Apply(t *testing.T) {
+	tcs := map[string]struct {
+		givenRounding Rounding
+		givenRate     float64
+		givenBase     Miles
+		expMiles      Miles
+	}{
+		"rate with two decimals":   {givenRounding: RoundHalfUp, givenRate: 0.65, givenBase: 241, expMiles: 157},
+		"rate with three decimals": {givenRounding: RoundDown, givenRate: 0.125, givenBase: 1000, expMiles: 125},
+		"half even rate":           {givenRounding: RoundHalfEven, givenRate: 0.5, givenBase: 5, expMiles: 2},
+		"zero rate":                {givenRounding: RoundUp, givenRate: 0, givenBase: 1000, expMiles: 0},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.givenRounding.Apply(tc.givenRate, tc.givenBase); got != tc.expMiles {
+				t.Fatalf("expected %d, got %d", tc.expMiles, got)
+			}
+		})
+	}
+}
+
+func TestRounding_Validate(t *testing.T) {
+	tcs := map[string]struct {
+		givenRounding Rounding
+		expErr        bool
+	}{
+		"default":   {givenRounding: ""},
+		"half up":   {givenRounding: RoundHalfUp},
+		"half even": {givenRounding: RoundHalfEven},
+		"down":      {givenRounding: RoundDown},
+		"up":        {givenRounding: RoundUp},
+		"unknown":   {givenRounding: "nearest", expErr: true},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.givenRounding.Validate(); (err != nil) != tc.expErr {
+				t.Fatalf("expected error %t, got %v", tc.expErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package accrualrate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/pagination"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetCharts(ctx context.Context, carrier string, page int, size int) ([]entity.AccrualRateChart, int64, error)
+
+	GetChart(ctx context.Context, id string) (entity.AccrualRateChart, error)
+
+	GetChartAt(ctx context.Context, carrier string, operatingCarrier string, at time.Time) (entity.AccrualRateChart, error)
+
+	GetOverlappingCharts(ctx context.Context, carrier string, operatingCarrier *string, validFrom time.Time, validTo *time.Time, excludeID string) ([]entity.AccrualRateChart, error)
+
+	SaveChart(ctx context.Context, chart entity.AccrualRateChart) error
+
+	DeleteChart(ctx context.Context, id string) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return repository{db: db}
+}
+
+func (r repository) GetCharts(ctx context.Context, carrier string, page int, size int) ([]entity.AccrualRateChart, int64, error) {
+	qb := r.db.WithContext(ctx).Model(&entity.AccrualRateChart{})
+
+	if carrier != "" {
+		qb = qb.Where("carrier = ?", carrier)
+	}
+
+	var total int64
+	if err := qb.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	qb = qb.Order("carrier, valid_from DESC")
+
+	offset, limit := pagination.ToSQLOffsetLimit(pagination.Pagination{Page: page, Size: size})
+	if offset > 0 {
+		qb = qb.Offset(offset)
+	}
+	if limit > 0 {
+		qb = qb.Limit(limit)
+	}
+
+	var charts []entity.AccrualRateChart
+	if err := qb.Find(&charts).Error; err != nil {
+		return nil, 0, err
+	}
+	return charts, total, nil
+}
+
+func (r repository) GetChart(ctx context.Context, id string) (entity.AccrualRateChart, error) {
+	var chart entity.AccrualRateChart
+	if err := r.db.WithContext(ctx).
+		Preload("Rates", func(db *gorm.DB) *gorm.DB { return db.Order("booking_class") }).
+		Where("id = ?", id).
+		First(&chart).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.AccrualRateChart{}, nil
+		}
+		return entity.AccrualRateChart{}, err
+	}
+	return chart, nil
+}
+
+func (r repository) GetChartAt(ctx context.Context, carrier string, operatingCarrier string, at time.Time) (entity.AccrualRateChart, error) {
+	// Charts are effective by calendar day, so compare against the start of the day
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+
+	var chart entity.AccrualRateChart
+	if err := r.db.WithContext(ctx).
+		Preload("Rates").
+		Where("carrier = ?", carrier).
+		Where("operating_carrier IS NULL OR operating_carrier = ?", operatingCarrier).
+		Where("valid_from <= ? AND (valid_to IS NULL OR valid_to >= ?)", day, day).
+		// Prefer a chart for the exact operating carrier over the marketing carrier's default
+		Order("operating_carrier IS NULL, valid_from DESC").
+		First(&chart).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.AccrualRateChart{}, nil
+		}
+		return entity.AccrualRateChart{}, err
+	}
+	return chart, nil
+}
+
+func (r repository) GetOverlappingCharts(ctx context.Context, carrier string, operatingCarrier *string, validFrom time.Time, validTo *time.Time, excludeID string) ([]entity.AccrualRateChart, error) {
+	qb := r.db.WithContext(ctx).
+		Where("carrier = ? AND operating_carrier IS NOT DISTINCT FROM ?", carrier, operatingCarrier).
+		Where("valid_to IS NULL OR valid_to >= ?", validFrom)
+	if validTo != nil {
+		qb = qb.Where("valid_from <= ?", *validTo)
+	}
+	if excludeID != "" {
+		qb = qb.Where("id <> ?", excludeID)
+	}
+
+	var charts []entity.AccrualRateChart
+	if err := qb.Find(&charts).Error; err != nil {
+		return nil, err
+	}
+	return charts, nil
+}
+
+func (r repository) SaveChart(ctx context.Context, chart entity.AccrualRateChart) error {
+	if chart.ID == uuid.Nil {
+		id, err := generator.AccrualRateChartID.Generate()
+		if err != nil {
+			return err
+		}
+		chart.ID = id
+	}
+
+	rates := chart.Rates
+	chart.Rates = nil
+	for idx := range rates {
+		if rates[idx].ID == uuid.Nil {
+			id, err := generator.AccrualRateID.Generate()
+			if err != nil {
+				return err
+			}
+			rates[idx].ID = id
+		}
+		rates[idx].ChartID = chart.ID
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&chart).Error; err != nil {
+			return err
+		}
+
+		// Rates are owned by the chart, so replace them as a whole
+		if err := tx.Where("chart_id = ?", chart.ID).Delete(&entity.AccrualRate{}).Error; err != nil {
+			return err
+		}
+		if len(rates) == 0 {
+			return nil
+		}
+		return tx.Create(&rates).Error
+	})
+}
+
+func (r repository) DeleteChart(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("chart_id = ?", id).Delete(&entity.AccrualRate{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entity.AccrualRateChart{}).Error
+	})
+}
@@ -0,0 +1,48 @@
+package airport
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetAirport(ctx context.Context, code string) (entity.Airport, error)
+
+	SearchAirports(ctx context.Context, query string, size int) ([]entity.Airport, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return repository{db: db}
+}
+
+func (r repository) GetAirport(ctx context.Context, code string) (entity.Airport, error) {
+	var airport entity.Airport
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&airport).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Airport{}, nil
+		}
+		return entity.Airport{}, err
+	}
+	return airport, nil
+}
+
+func (r repository) SearchAirports(ctx context.Context, query string, size int) ([]entity.Airport, error) {
+	var airports []entity.Airport
+	if err := r.db.WithContext(ctx).
+		Where("code ILIKE ? OR name ILIKE ? OR city ILIKE ?", query+"%", "%"+query+"%", "%"+query+"%").
+		// Exact code first, then codes starting with the query, then name and city matches
+		Order(clause.Expr{SQL: "code = UPPER(?) DESC, code ILIKE ? DESC, code", Vars: []interface{}{query, query + "%"}, WithoutParentheses: true}).
+		Limit(size).
+		Find(&airports).Error; err != nil {
+		return nil, err
+	}
+	return airports, nil
+}
@@ -0,0 +1,66 @@
+package autoapproval
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"gorm.io/gorm"
+)
+
+// policyID is the row of the only policy
+const policyID = 1
+
+type Repository interface {
+	// GetPolicy returns the policy, a missing row reads as a disabled policy
+	GetPolicy(ctx context.Context) (entity.AutoApprovalPolicy, error)
+
+	SavePolicy(ctx context.Context, policy entity.AutoApprovalPolicy) error
+
+	// GetStats counts the requests auto approved between from and to, and how many of them were reversed since
+	GetStats(ctx context.Context, from time.Time, to time.Time) (approved int64, reversed int64, credited miles.Miles, err error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return repository{db: db}
+}
+
+func (r repository) GetPolicy(ctx context.Context) (entity.AutoApprovalPolicy, error) {
+	var policy entity.AutoApprovalPolicy
+	if err := r.db.WithContext(ctx).Where("id = ?", policyID).First(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.AutoApprovalPolicy{ID: policyID}, nil
+		}
+		return entity.AutoApprovalPolicy{}, err
+	}
+	return policy, nil
+}
+
+func (r repository) SavePolicy(ctx context.Context, policy entity.AutoApprovalPolicy) error {
+	policy.ID = policyID
+	return r.db.WithContext(ctx).Save(&policy).Error
+}
+
+func (r repository) GetStats(ctx context.Context, from time.Time, to time.Time) (int64, int64, miles.Miles, error) {
+	var stats struct {
+		Approved int64
+		Reversed int64
+		Credited miles.Miles
+	}
+	if err := r.db.WithContext(ctx).Model(&entity.AccrualRequest{}).
+		Select(`COUNT(*) AS approved,
+			COUNT(*) FILTER (WHERE status = ?) AS reversed,
+			COALESCE(SUM(qualifying_miles + bonus_miles + tier_bonus_miles), 0) AS credited`, constants.RequestStatusReversed).
+		Where("auto_approved AND reviewed_at >= ? AND reviewed_at < ?", from, to).
+		Scan(&stats).Error; err != nil {
+		return 0, 0, 0, err
+	}
+	return stats.Approved, stats.Reversed, stats.Credited, nil
+}
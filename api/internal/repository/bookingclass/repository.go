@@ -0,0 +1,42 @@
+package bookingclass
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetBookingClasses(ctx context.Context) ([]entity.BookingClass, error)
+
+	GetBookingClass(ctx context.Context, code string) (entity.BookingClass, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return repository{db: db}
+}
+
+func (r repository) GetBookingClasses(ctx context.Context) ([]entity.BookingClass, error) {
+	var classes []entity.BookingClass
+	if err := r.db.WithContext(ctx).Order("sort_order, code").Find(&classes).Error; err != nil {
+		return nil, err
+	}
+	return classes, nil
+}
+
+func (r repository) GetBookingClass(ctx context.Context, code string) (entity.BookingClass, error) {
+	var class entity.BookingClass
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&class).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.BookingClass{}, nil
+		}
+		return entity.BookingClass{}, err
+	}
+	return class, nil
+}
@@ -0,0 +1,153 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/pagination"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetCampaigns(ctx context.Context, status string, page int, size int) ([]entity.Campaign, int64, error)
+
+	GetCampaign(ctx context.Context, id string) (entity.Campaign, error)
+
+	GetRunningCampaigns(ctx context.Context, at time.Time) ([]entity.Campaign, error)
+
+	SaveCampaign(ctx context.Context, campaign entity.Campaign) error
+
+	SaveOptIn(ctx context.Context, optIn entity.CampaignOptIn) error
+
+	GetOptedInCampaignIDs(ctx context.Context, customerID uuid.UUID, campaignIDs []uuid.UUID) ([]uuid.UUID, error)
+
+	CountOptIns(ctx context.Context, campaignID string) (int64, error)
+
+	GetAwardStats(ctx context.Context, campaignID string) (awards int64, customers int64, miles miles.Miles, err error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return repository{db: db}
+}
+
+func (r repository) GetCampaigns(ctx context.Context, status string, page int, size int) ([]entity.Campaign, int64, error) {
+	qb := r.db.WithContext(ctx).Model(&entity.Campaign{})
+
+	if status != "" {
+		qb = qb.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := qb.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	qb = qb.Order("starts_at DESC")
+
+	offset, limit := pagination.ToSQLOffsetLimit(pagination.Pagination{Page: page, Size: size})
+	if offset > 0 {
+		qb = qb.Offset(offset)
+	}
+	if limit > 0 {
+		qb = qb.Limit(limit)
+	}
+
+	var campaigns []entity.Campaign
+	if err := qb.Find(&campaigns).Error; err != nil {
+		return nil, 0, err
+	}
+	return campaigns, total, nil
+}
+
+func (r repository) GetCampaign(ctx context.Context, id string) (entity.Campaign, error) {
+	var campaign entity.Campaign
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&campaign).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Campaign{}, nil
+		}
+		return entity.Campaign{}, err
+	}
+	return campaign, nil
+}
+
+func (r repository) GetRunningCampaigns(ctx context.Context, at time.Time) ([]entity.Campaign, error) {
+	var campaigns []entity.Campaign
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", constants.CampaignStatusScheduled).
+		Where("starts_at <= ? AND (ends_at IS NULL OR ends_at >= ?)", at, at).
+		Order("starts_at").
+		Find(&campaigns).Error; err != nil {
+		return nil, err
+	}
+	return campaigns, nil
+}
+
+func (r repository) SaveCampaign(ctx context.Context, campaign entity.Campaign) error {
+	if campaign.ID == uuid.Nil {
+		id, err := generator.CampaignID.Generate()
+		if err != nil {
+			return err
+		}
+		campaign.ID = id
+	}
+
+	return r.db.WithContext(ctx).Save(&campaign).Error
+}
+
+func (r repository) SaveOptIn(ctx context.Context, optIn entity.CampaignOptIn) error {
+	// Opting in twice is a no-op
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&optIn).Error
+}
+
+func (r repository) GetOptedInCampaignIDs(ctx context.Context, customerID uuid.UUID, campaignIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(campaignIDs) == 0 {
+		return nil, nil
+	}
+
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&entity.CampaignOptIn{}).
+		Where("customer_id = ? AND campaign_id IN ?", customerID, campaignIDs).
+		Pluck("campaign_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r repository) CountOptIns(ctx context.Context, campaignID string) (int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).
+		Model(&entity.CampaignOptIn{}).
+		Where("campaign_id = ?", campaignID).
+		Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r repository) GetAwardStats(ctx context.Context, campaignID string) (int64, int64, miles.Miles, error) {
+	var stats struct {
+		Awards    int64
+		Customers int64
+		Miles     miles.Miles
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&entity.MilesLedger{}).
+		Select("COUNT(*) AS awards, COUNT(DISTINCT customer_id) AS customers, COALESCE(SUM(bonus_miles_delta), 0) AS miles").
+		Where("campaign_id = ? AND kind = ?", campaignID, constants.LedgerKindPromotion).
+		Scan(&stats).Error; err != nil {
+		return 0, 0, 0, err
+	}
+	return stats.Awards, stats.Customers, stats.Miles, nil
+}
@@ -0,0 +1,38 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetRateAt(ctx context.Context, fromCurrency string, toCurrency string, at time.Time) (entity.ExchangeRate, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return repository{db: db}
+}
+
+func (r repository) GetRateAt(ctx context.Context, fromCurrency string, toCurrency string, at time.Time) (entity.ExchangeRate, error) {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+
+	var rate entity.ExchangeRate
+	if err := r.db.WithContext(ctx).
+		Where("from_currency = ? AND to_currency = ? AND valid_from <= ?", fromCurrency, toCurrency, day).
+		Order("valid_from DESC").
+		First(&rate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.ExchangeRate{}, nil
+		}
+		return entity.ExchangeRate{}, err
+	}
+	return rate, nil
+}
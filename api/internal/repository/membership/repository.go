@@ -4,11 +4,12 @@ import (
 	"context"
 
 	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	GetCustomerQualifyingMiles(ctx context.Context, customerID string) (float64, error)
+	GetCustomerQualifyingMiles(ctx context.Context, customerID string) (miles.Miles, error)
 
 	UpdateCustomerMembershipTier(ctx context.Context, customerID string, memberTier string) error
 
@@ -29,7 +30,7 @@ func NewRepository(db *gorm.DB) Repository {
 	}
 }
 
-func (r repository) GetCustomerQualifyingMiles(ctx context.Context, customerID string) (float64, error) {
+func (r repository) GetCustomerQualifyingMiles(ctx context.Context, customerID string) (miles.Miles, error) {
 	var customer entity.Customer
 	if err := r.db.WithContext(ctx).Select("qualifying_miles_total").Where("id = ?", customerID).First(&customer).Error; err != nil {
 		return 0, err
@@ -0,0 +1,49 @@
+package mileage
+
+import (
+	"context"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
+	"gorm.io/gorm"
+)
+
+func (r repository) GetAccrualRequestComments(ctx context.Context, accrualRequestID string, includeInternal bool) ([]entity.AccrualRequestComment, error) {
+	qb := r.db.WithContext(ctx).Where("accrual_request_id = ?", accrualRequestID)
+	if !includeInternal {
+		qb = qb.Where("NOT internal")
+	}
+
+	var comments []entity.AccrualRequestComment
+	if err := qb.Order("created_at").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r repository) SaveAccrualRequestComment(ctx context.Context, comment entity.AccrualRequestComment, event entity.NotificationEvent) (entity.AccrualRequestComment, error) {
+	commentID, err := generator.CommentID.Generate()
+	if err != nil {
+		return entity.AccrualRequestComment{}, err
+	}
+	comment.ID = commentID
+
+	eventID, err := generator.NotificationEventID.Generate()
+	if err != nil {
+		return entity.AccrualRequestComment{}, err
+	}
+	event.ID = eventID
+	event.Payload["comment_id"] = commentID
+
+	// The event is written in the same transaction so a comment is never left without its notification
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+		return tx.Create(&event).Error
+	}); err != nil {
+		return entity.AccrualRequestComment{}, err
+	}
+
+	return comment, nil
+}
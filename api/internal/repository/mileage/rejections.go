@@ -0,0 +1,30 @@
+package mileage
+
+import (
+	"context"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+)
+
+type RejectionStats struct {
+	Code     string
+	Segments int64
+	Requests int64
+}
+
+func (r repository) GetRejectionStats(ctx context.Context, from time.Time, to time.Time) ([]RejectionStats, error) {
+	var stats []RejectionStats
+	if err := r.db.WithContext(ctx).Model(&entity.AccrualRequestSegment{}).
+		Select(`rejected_reason_code AS code,
+			COUNT(*) AS segments,
+			COUNT(DISTINCT accrual_request_id) AS requests`).
+		Where("status = ? AND rejected_reason_code IS NOT NULL", constants.SegmentStatusRejected).
+		Where("updated_at >= ? AND updated_at < ?", from, to).
+		Group("rejected_reason_code").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
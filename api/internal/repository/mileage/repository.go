@@ -5,33 +5,94 @@ import (
 	"errors"
 	"time"
 
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
 	"github.com/erwin-lovecraft/aegismiles/internal/entity"
 	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
 	"github.com/erwin-lovecraft/aegismiles/internal/pkg/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
-	GetAccrualRequests(ctx context.Context, keyword string, customerID string, status string, submittedDate time.Time, page int, size int) ([]entity.AccrualRequest, int64, error)
+	GetAccrualRequests(ctx context.Context, keyword string, customerID string, status string, submittedDate time.Time, minRiskScore int, page int, size int) ([]entity.AccrualRequest, int64, error)
 
-	GetAccrualRequestByFilter(ctx context.Context, customerID string, ticketID string, pnr string) (entity.AccrualRequest, error)
+	// GetRepricingCandidates returns the requests in one of the statuses with a segment matching every given filter
+	GetRepricingCandidates(ctx context.Context, statuses []string, requestIDs []string, chartID string, carrier string, departureFrom *time.Time, departureTo *time.Time) ([]entity.AccrualRequest, error)
+
+	GetClaimedSegment(ctx context.Context, customerID string, segment entity.AccrualRequestSegment) (entity.AccrualRequestSegment, error)
 
 	GetTravelDistance(ctx context.Context, fromCode string, toCode string) (entity.TravelDistance, error)
 
 	SaveAccrualRequest(ctx context.Context, accrualRequest entity.AccrualRequest) error
 
+	// UpdateAccrualRequest saves a loaded request unless it changed since loading or, for a reviewer, another reviewer holds the lock.
+	// The ledger rows are written as pending in the same transaction. It reports whether the request was saved
+	UpdateAccrualRequest(ctx context.Context, accrualRequest entity.AccrualRequest, loadedStatus string, reviewerID string, now time.Time, ledgers ...entity.MilesLedger) (bool, error)
+
+	// RepriceAccrualRequest saves a re-priced request like UpdateAccrualRequest, together with its audit records and pending correction rows
+	RepriceAccrualRequest(ctx context.Context, accrualRequest entity.AccrualRequest, loadedStatus string, reviewerID string, now time.Time, repricings []entity.AccrualRepricing, ledgers []entity.MilesLedger) (bool, error)
+
 	GetAccrualRequest(ctx context.Context, id string) (entity.AccrualRequest, error)
 
-	IncreaseCustomerMiles(ctx context.Context, customerID string, qMiles float64, bMiles float64) error
+	// GetAccrualRequestDetail loads the request with its customer and full status and revision history
+	GetAccrualRequestDetail(ctx context.Context, id string) (entity.AccrualRequest, error)
+
+	IncreaseCustomerMiles(ctx context.Context, customerID string, qMiles miles.Miles, bMiles miles.Miles) error
 
 	SaveMileageLedger(ctx context.Context, e entity.MilesLedger) error
 
+	// PostLedgerMiles moves the customer's miles by a pending ledger row and marks it posted, a posted row is left alone
+	PostLedgerMiles(ctx context.Context, referenceID string, now time.Time) error
+
+	// MarkLedgerPosted records that the points account has moved for a pending ledger row
+	MarkLedgerPosted(ctx context.Context, referenceID string, now time.Time) error
+
+	// FailLedgerPosting keeps why the points account could not be moved for a pending ledger row
+	FailLedgerPosting(ctx context.Context, referenceID string, reason string) error
+
 	GetMileageLedgers(ctx context.Context, customerID string, date time.Time, page int, size int) ([]entity.MilesLedger, int64, error)
 
+	// GetAccrualRequestLedgers returns every ledger row linked to the request, oldest first
+	GetAccrualRequestLedgers(ctx context.Context, accrualRequestID string) ([]entity.MilesLedger, error)
+
+	// GetPendingLedgers returns the ledger rows of the request the points account has not moved for yet, oldest first
+	GetPendingLedgers(ctx context.Context, accrualRequestID string) ([]entity.MilesLedger, error)
+
 	GetCustomersWithPositiveQMDeltasForMonth(ctx context.Context, monthToExpire time.Time) ([]int64, error)
 
-	GetTotalQMDeltasForCustomerAndMonth(ctx context.Context, customerID string, monthToExpire time.Time) (float64, error)
+	GetTotalQMDeltasForCustomerAndMonth(ctx context.Context, customerID string, monthToExpire time.Time) (miles.Miles, error)
+
+	// GetReviewQueue returns the open requests a reviewer can pick up, or only the ones assigned to them when mine is set.
+	// Gold and higher tiers come first, then the oldest submissions
+	GetReviewQueue(ctx context.Context, reviewerID string, mine bool, now time.Time, page int, size int) ([]entity.AccrualRequest, int64, error)
+
+	// LockAccrualRequest assigns an open request to the reviewer unless another reviewer holds a lock, it reports whether the lock was taken
+	LockAccrualRequest(ctx context.Context, id string, reviewerID string, now time.Time, until time.Time) (bool, error)
+
+	// UnlockAccrualRequest releases the reviewer's lock, it reports whether the reviewer held one
+	UnlockAccrualRequest(ctx context.Context, id string, reviewerID string) (bool, error)
+
+	// CountOtherClaimants counts the other customers with an active claim on one of the tickets
+	CountOtherClaimants(ctx context.Context, customerID uuid.UUID, ticketIDs []string) (int64, error)
+
+	// CountSameDayFlights counts the customer's flights on other tickets departing on one of the dates
+	CountSameDayFlights(ctx context.Context, customerID uuid.UUID, excludeRequestID uuid.UUID, ticketIDs []string, departureDates []time.Time) (int64, error)
+
+	CountClaimsSince(ctx context.Context, customerID uuid.UUID, excludeRequestID uuid.UUID, since time.Time) (int64, error)
+
+	// GetAccrualRequestComments returns the request's comments oldest first, internal ones only when asked for
+	GetAccrualRequestComments(ctx context.Context, accrualRequestID string, includeInternal bool) ([]entity.AccrualRequestComment, error)
+
+	// SaveAccrualRequestComment stores the comment together with the notification it raises, the new comment's ID is added to the event payload
+	SaveAccrualRequestComment(ctx context.Context, comment entity.AccrualRequestComment, event entity.NotificationEvent) (entity.AccrualRequestComment, error)
+
+	// GetRejectionStats counts the segments rejected between from and to per reason code, segments are dated by their last update
+	GetRejectionStats(ctx context.Context, from time.Time, to time.Time) ([]RejectionStats, error)
+
+	// GetReviewerStats counts the decisions made between from and to and the open requests assigned, per reviewer
+	GetReviewerStats(ctx context.Context, slaHours int, from time.Time, to time.Time, now time.Time) ([]ReviewerStats, error)
 }
 
 type repository struct {
@@ -42,7 +103,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return repository{db: db}
 }
 
-func (r repository) GetAccrualRequests(ctx context.Context, keyword string, customerID string, status string, submittedDate time.Time, page int, size int) ([]entity.AccrualRequest, int64, error) {
+func (r repository) GetAccrualRequests(ctx context.Context, keyword string, customerID string, status string, submittedDate time.Time, minRiskScore int, page int, size int) ([]entity.AccrualRequest, int64, error) {
 	qb := r.db.WithContext(ctx).Model(&entity.AccrualRequest{})
 
 	if keyword != "" {
@@ -70,6 +131,9 @@ func (r repository) GetAccrualRequests(ctx context.Context, keyword string, cust
 	if !submittedDate.IsZero() {
 		qb = qb.Where("created_at = ?", submittedDate)
 	}
+	if minRiskScore > 0 {
+		qb = qb.Where("risk_score >= ?", minRiskScore)
+	}
 
 	var total int64
 	if err := qb.Count(&total).Error; err != nil {
@@ -86,7 +150,7 @@ func (r repository) GetAccrualRequests(ctx context.Context, keyword string, cust
 		qb = qb.Limit(limit)
 	}
 
-	qb = qb.Preload("Customer")
+	qb = qb.Preload("Customer").Preload("Segments", orderBySegmentNo).Preload("Revisions", orderByRevisionNo)
 
 	var accrualRequests []entity.AccrualRequest
 	if err := qb.Find(&accrualRequests).Error; err != nil {
@@ -95,24 +159,33 @@ func (r repository) GetAccrualRequests(ctx context.Context, keyword string, cust
 	return accrualRequests, total, nil
 }
 
-func (r repository) GetAccrualRequestByFilter(ctx context.Context, customerID string, ticketID string, pnr string) (entity.AccrualRequest, error) {
-	qb := r.db.WithContext(ctx)
-	if customerID != "" {
-		qb = qb.Where("customer_id = ?", customerID)
-	}
-	if ticketID != "" {
-		qb = qb.Where("ticket_id = ?", ticketID)
-	}
-	if pnr != "" {
-		qb = qb.Where("pnr = ?", pnr)
+func (r repository) GetClaimedSegment(ctx context.Context, customerID string, segment entity.AccrualRequestSegment) (entity.AccrualRequestSegment, error) {
+	qb := r.db.WithContext(ctx).
+		Joins("JOIN accrual_requests ON accrual_requests.id = accrual_request_segments.accrual_request_id").
+		Where("accrual_requests.customer_id = ?", customerID).
+		Where("accrual_requests.status <> ?", constants.RequestStatusCancelled).
+		Where("accrual_request_segments.status <> ?", constants.SegmentStatusRejected).
+		Where("accrual_request_segments.ticket_id = ?", segment.TicketID)
+
+	// A coupon identifies the flown segment on a ticket, without it fall back to the route and date.
+	// Matching the exact departure date is intended: one ticket does not fly the same route twice on a day,
+	// while a return on the same route on another day is a different coupon
+	if segment.CouponNumber > 0 {
+		qb = qb.Where("accrual_request_segments.coupon_number = ?", segment.CouponNumber)
+	} else {
+		qb = qb.Where("accrual_request_segments.from_code = ? AND accrual_request_segments.to_code = ? AND accrual_request_segments.departure_date = ?",
+			segment.FromCode, segment.ToCode, segment.DepartureDate)
 	}
 
-	var accrualRequest entity.AccrualRequest
-	if err := qb.Find(&accrualRequest).Error; err != nil {
-		return entity.AccrualRequest{}, err
+	var claimed entity.AccrualRequestSegment
+	if err := qb.First(&claimed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.AccrualRequestSegment{}, nil
+		}
+		return entity.AccrualRequestSegment{}, err
 	}
 
-	return accrualRequest, nil
+	return claimed, nil
 }
 
 func (r repository) GetTravelDistance(ctx context.Context, fromCode string, toCode string) (entity.TravelDistance, error) {
@@ -121,25 +194,227 @@ func (r repository) GetTravelDistance(ctx context.Context, fromCode string, toCo
 		Where("from_code = ? AND to_code = ?", fromCode, toCode).
 		Or("from_code = ? AND to_code = ?", toCode, fromCode).
 		First(&travelDistance).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.TravelDistance{}, nil
+		}
 		return entity.TravelDistance{}, err
 	}
 	return travelDistance, nil
 }
 
 func (r repository) SaveAccrualRequest(ctx context.Context, accrualRequest entity.AccrualRequest) error {
+	children, err := prepareChildren(&accrualRequest)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(&accrualRequest).Error; err != nil {
+			return err
+		}
+		return children.save(tx)
+	})
+}
+
+func (r repository) UpdateAccrualRequest(ctx context.Context, accrualRequest entity.AccrualRequest, loadedStatus string, reviewerID string, now time.Time, ledgers ...entity.MilesLedger) (bool, error) {
+	return r.updateAccrualRequest(ctx, accrualRequest, loadedStatus, reviewerID, now, ledgers, nil)
+}
+
+func (r repository) RepriceAccrualRequest(ctx context.Context, accrualRequest entity.AccrualRequest, loadedStatus string, reviewerID string, now time.Time, repricings []entity.AccrualRepricing, ledgers []entity.MilesLedger) (bool, error) {
+	return r.updateAccrualRequest(ctx, accrualRequest, loadedStatus, reviewerID, now, ledgers, repricings)
+}
+
+func (r repository) updateAccrualRequest(ctx context.Context, accrualRequest entity.AccrualRequest, loadedStatus string, reviewerID string, now time.Time, ledgers []entity.MilesLedger, repricings []entity.AccrualRepricing) (bool, error) {
+	children, err := prepareChildren(&accrualRequest)
+	if err != nil {
+		return false, err
+	}
+
+	for idx := range repricings {
+		if repricings[idx].ID == uuid.Nil {
+			id, err := generator.RepricingID.Generate()
+			if err != nil {
+				return false, err
+			}
+			repricings[idx].ID = id
+		}
+	}
+	children.repricings = repricings
+
+	for idx := range ledgers {
+		if ledgers[idx].ID == uuid.Nil {
+			id, err := generator.MilesLedgerID.Generate()
+			if err != nil {
+				return false, err
+			}
+			ledgers[idx].ID = id
+		}
+		ledgers[idx].PostedAt = nil
+	}
+	children.ledgers = ledgers
+
+	// A lock taken since loading must survive the save, only a transition out of review releases it
+	omit := []string{clause.Associations, "id", "created_at", "assigned_to"}
+	if accrualRequest.LockedUntil != nil {
+		omit = append(omit, "locked_until")
+	}
+
+	// Segment decisions leave the request status alone, the timestamp tells them apart
+	loadedAt := accrualRequest.UpdatedAt
+
+	saved := false
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		qb := tx.Model(&entity.AccrualRequest{}).Where("id = ? AND status = ? AND updated_at = ?", accrualRequest.ID, loadedStatus, loadedAt)
+		if reviewerID != "" {
+			qb = qb.Where("(locked_until IS NULL OR locked_until <= ? OR assigned_to = ?)", now, reviewerID)
+		}
+
+		result := qb.Select("*").Omit(omit...).Updates(&accrualRequest)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// Someone else decided on the request or locked it since it was loaded
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		saved = true
+		return children.save(tx)
+	})
+	if err != nil {
+		return false, err
+	}
+	return saved, nil
+}
+
+// requestChildren are the rows saved along with an accrual request
+type requestChildren struct {
+	segments   []entity.AccrualRequestSegment
+	events     []entity.AccrualRequestEvent
+	revisions  []entity.AccrualRequestRevision
+	ledgers    []entity.MilesLedger
+	repricings []entity.AccrualRepricing
+}
+
+// prepareChildren detaches the associations from the request and assigns IDs to the new rows
+func prepareChildren(accrualRequest *entity.AccrualRequest) (requestChildren, error) {
 	if accrualRequest.ID == uuid.Nil {
 		id, err := generator.AccrualRequestID.Generate()
 		if err != nil {
-			return err
+			return requestChildren{}, err
 		}
 		accrualRequest.ID = id
 	}
-	return r.db.WithContext(ctx).Save(&accrualRequest).Error
+
+	segments := accrualRequest.Segments
+	accrualRequest.Segments = nil
+	for idx := range segments {
+		if segments[idx].ID == uuid.Nil {
+			id, err := generator.SegmentID.Generate()
+			if err != nil {
+				return requestChildren{}, err
+			}
+			segments[idx].ID = id
+		}
+		segments[idx].AccrualRequestID = accrualRequest.ID
+	}
+
+	// Events and revisions are append only, only the ones recorded since loading are new
+	events := accrualRequest.Events
+	accrualRequest.Events = nil
+	var newEvents []entity.AccrualRequestEvent
+	for _, event := range events {
+		if event.ID != uuid.Nil {
+			continue
+		}
+
+		id, err := generator.RequestEventID.Generate()
+		if err != nil {
+			return requestChildren{}, err
+		}
+		event.ID = id
+		event.AccrualRequestID = accrualRequest.ID
+		newEvents = append(newEvents, event)
+	}
+
+	revisions := accrualRequest.Revisions
+	accrualRequest.Revisions = nil
+	var newRevisions []entity.AccrualRequestRevision
+	for _, revision := range revisions {
+		if revision.ID != uuid.Nil {
+			continue
+		}
+
+		id, err := generator.RevisionID.Generate()
+		if err != nil {
+			return requestChildren{}, err
+		}
+		revision.ID = id
+		revision.AccrualRequestID = accrualRequest.ID
+		newRevisions = append(newRevisions, revision)
+	}
+
+	return requestChildren{segments: segments, events: newEvents, revisions: newRevisions}, nil
+}
+
+func (c requestChildren) save(tx *gorm.DB) error {
+	// Segments change status on their own, so save each one rather than relying on association upserts
+	for idx := range c.segments {
+		if err := tx.Save(&c.segments[idx]).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(c.events) > 0 {
+		if err := tx.Create(&c.events).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(c.revisions) > 0 {
+		if err := tx.Create(&c.revisions).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(c.ledgers) > 0 {
+		if err := tx.Create(&c.ledgers).Error; err != nil {
+			return err
+		}
+	}
+
+	// Audit records point at their correction rows, so they go last
+	if len(c.repricings) > 0 {
+		if err := tx.Create(&c.repricings).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r repository) GetAccrualRequest(ctx context.Context, id string) (entity.AccrualRequest, error) {
 	var accrualRequest entity.AccrualRequest
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&accrualRequest).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Preload("Segments", orderBySegmentNo).
+		Preload("Revisions", orderByRevisionNo).
+		Where("id = ?", id).First(&accrualRequest).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.AccrualRequest{}, nil
+		}
+		return entity.AccrualRequest{}, err
+	}
+	return accrualRequest, nil
+}
+
+func (r repository) GetAccrualRequestDetail(ctx context.Context, id string) (entity.AccrualRequest, error) {
+	var accrualRequest entity.AccrualRequest
+	if err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Preload("Segments", orderBySegmentNo).
+		Preload("Events", orderByCreatedAt).
+		Preload("Revisions", orderByRevisionNo).
+		Where("id = ?", id).First(&accrualRequest).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return entity.AccrualRequest{}, nil
 		}
@@ -148,7 +423,7 @@ func (r repository) GetAccrualRequest(ctx context.Context, id string) (entity.Ac
 	return accrualRequest, nil
 }
 
-func (r repository) IncreaseCustomerMiles(ctx context.Context, customerID string, qMiles float64, bMiles float64) error {
+func (r repository) IncreaseCustomerMiles(ctx context.Context, customerID string, qMiles miles.Miles, bMiles miles.Miles) error {
 	if err := r.db.WithContext(ctx).Model(entity.Customer{}).
 		Where("id = ?", customerID).
 		Updates(map[string]interface{}{
@@ -168,9 +443,59 @@ func (r repository) SaveMileageLedger(ctx context.Context, e entity.MilesLedger)
 		}
 		e.ID = id
 	}
+
+	// Rows saved on their own record miles that have already moved
+	if e.PostedAt == nil {
+		now := time.Now().UTC()
+		e.PostedAt = &now
+	}
 	return r.db.WithContext(ctx).Save(&e).Error
 }
 
+func (r repository) PostLedgerMiles(ctx context.Context, referenceID string, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ledger entity.MilesLedger
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("reference_id = ?", referenceID).
+			Take(&ledger).Error; err != nil {
+			return err
+		}
+
+		if ledger.PostedAt != nil {
+			return nil
+		}
+
+		if err := tx.Model(entity.Customer{}).
+			Where("id = ?", ledger.CustomerID).
+			Updates(map[string]interface{}{
+				"qualifying_miles_total": gorm.Expr("qualifying_miles_total + ?", ledger.QualifyingMilesDelta),
+				"bonus_miles_total":      gorm.Expr("bonus_miles_total + ?", ledger.BonusMilesDelta),
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&ledger).Updates(map[string]interface{}{
+			"posted_at":     now,
+			"posting_error": nil,
+		}).Error
+	})
+}
+
+func (r repository) MarkLedgerPosted(ctx context.Context, referenceID string, now time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.MilesLedger{}).
+		Where("reference_id = ? AND posted_at IS NULL", referenceID).
+		Updates(map[string]interface{}{
+			"posted_at":     now,
+			"posting_error": nil,
+		}).Error
+}
+
+func (r repository) FailLedgerPosting(ctx context.Context, referenceID string, reason string) error {
+	return r.db.WithContext(ctx).Model(&entity.MilesLedger{}).
+		Where("reference_id = ? AND posted_at IS NULL", referenceID).
+		Update("posting_error", reason).Error
+}
+
 func (r repository) GetMileageLedgers(ctx context.Context, customerID string, date time.Time, page int, size int) ([]entity.MilesLedger, int64, error) {
 	qb := r.db.WithContext(ctx).Model(&entity.MilesLedger{})
 
@@ -202,6 +527,28 @@ func (r repository) GetMileageLedgers(ctx context.Context, customerID string, da
 	return accrualRequests, total, nil
 }
 
+func (r repository) GetAccrualRequestLedgers(ctx context.Context, accrualRequestID string) ([]entity.MilesLedger, error) {
+	var ledgers []entity.MilesLedger
+	if err := r.db.WithContext(ctx).
+		Where("accrual_request_id = ?", accrualRequestID).
+		Order("created_at").
+		Find(&ledgers).Error; err != nil {
+		return nil, err
+	}
+	return ledgers, nil
+}
+
+func (r repository) GetPendingLedgers(ctx context.Context, accrualRequestID string) ([]entity.MilesLedger, error) {
+	var ledgers []entity.MilesLedger
+	if err := r.db.WithContext(ctx).
+		Where("accrual_request_id = ? AND posted_at IS NULL", accrualRequestID).
+		Order("created_at").
+		Find(&ledgers).Error; err != nil {
+		return nil, err
+	}
+	return ledgers, nil
+}
+
 func (r repository) GetCustomersWithPositiveQMDeltasForMonth(ctx context.Context, monthToExpire time.Time) ([]int64, error) {
 	var customerIDs []int64
 
@@ -218,8 +565,8 @@ func (r repository) GetCustomersWithPositiveQMDeltasForMonth(ctx context.Context
 	return customerIDs, err
 }
 
-func (r repository) GetTotalQMDeltasForCustomerAndMonth(ctx context.Context, customerID string, monthToExpire time.Time) (float64, error) {
-	var total float64
+func (r repository) GetTotalQMDeltasForCustomerAndMonth(ctx context.Context, customerID string, monthToExpire time.Time) (miles.Miles, error) {
+	var total miles.Miles
 
 	// Get first day of the month
 	monthStart := time.Date(monthToExpire.Year(), monthToExpire.Month(), 1, 0, 0, 0, 0, monthToExpire.Location())
@@ -233,3 +580,47 @@ func (r repository) GetTotalQMDeltasForCustomerAndMonth(ctx context.Context, cus
 
 	return total, err
 }
+
+func (r repository) GetRepricingCandidates(ctx context.Context, statuses []string, requestIDs []string, chartID string, carrier string, departureFrom *time.Time, departureTo *time.Time) ([]entity.AccrualRequest, error) {
+	qb := r.db.WithContext(ctx).Model(&entity.AccrualRequest{}).Where("status IN ?", statuses)
+
+	if len(requestIDs) > 0 {
+		qb = qb.Where("id IN ?", requestIDs)
+	}
+
+	segments := r.db.Session(&gorm.Session{NewDB: true}).
+		Model(&entity.AccrualRequestSegment{}).
+		Select("1").
+		Where("accrual_request_segments.accrual_request_id = accrual_requests.id")
+	if chartID != "" {
+		segments = segments.Where("accrual_rate_chart_id = ?", chartID)
+	}
+	if carrier != "" {
+		segments = segments.Where("carrier = ?", carrier)
+	}
+	if departureFrom != nil {
+		segments = segments.Where("departure_date >= ?", *departureFrom)
+	}
+	if departureTo != nil {
+		segments = segments.Where("departure_date <= ?", *departureTo)
+	}
+	qb = qb.Where("EXISTS (?)", segments)
+
+	var accrualRequests []entity.AccrualRequest
+	if err := qb.Order("created_at").Preload("Segments", orderBySegmentNo).Find(&accrualRequests).Error; err != nil {
+		return nil, err
+	}
+	return accrualRequests, nil
+}
+
+func orderBySegmentNo(db *gorm.DB) *gorm.DB {
+	return db.Order("segment_no")
+}
+
+func orderByRevisionNo(db *gorm.DB) *gorm.DB {
+	return db.Order("revision_no")
+}
+
+func orderByCreatedAt(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at")
+}
@@ -0,0 +1,142 @@
+package mileage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/pagination"
+	"gorm.io/gorm/clause"
+)
+
+// ReviewerStats is one reviewer's throughput against the review SLA
+type ReviewerStats struct {
+	ReviewerID     string
+	Reviewed       int64
+	Breached       int64 // Decided later than the SLA
+	AvgReviewHours float64
+	Open           int64
+	OpenBreached   int64 // Still open past the SLA
+}
+
+var (
+	openStatuses    = []string{constants.RequestStatusSubmitted, constants.RequestStatusUnderReview, constants.RequestStatusPendingSecondApproval}
+	decidedStatuses = []string{constants.RequestStatusApproved, constants.RequestStatusRejected, constants.RequestStatusReversed}
+	priorityTiers   = []string{constants.MemberTierGold, constants.MemberTierPlatinum, constants.MemberTierMillionMiler}
+)
+
+func (r repository) GetReviewQueue(ctx context.Context, reviewerID string, mine bool, now time.Time, page int, size int) ([]entity.AccrualRequest, int64, error) {
+	qb := r.db.WithContext(ctx).Model(&entity.AccrualRequest{}).Where("status IN ?", openStatuses)
+
+	if mine {
+		qb = qb.Where("assigned_to = ?", reviewerID)
+	} else {
+		qb = qb.Where("(locked_until IS NULL OR locked_until < ? OR assigned_to = ?)", now, reviewerID)
+	}
+
+	var total int64
+	if err := qb.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	qb = qb.Clauses(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "CASE WHEN member_tier IN ? THEN 0 ELSE 1 END, created_at",
+		Vars: []interface{}{priorityTiers},
+	}})
+
+	offset, limit := pagination.ToSQLOffsetLimit(pagination.Pagination{Page: page, Size: size})
+	if offset > 0 {
+		qb = qb.Offset(offset)
+	}
+	if limit > 0 {
+		qb = qb.Limit(limit)
+	}
+
+	var accrualRequests []entity.AccrualRequest
+	if err := qb.Preload("Customer").Preload("Segments", orderBySegmentNo).Find(&accrualRequests).Error; err != nil {
+		return nil, 0, err
+	}
+	return accrualRequests, total, nil
+}
+
+func (r repository) LockAccrualRequest(ctx context.Context, id string, reviewerID string, now time.Time, until time.Time) (bool, error) {
+	// A single conditional update, so two reviewers claiming at once cannot both win
+	result := r.db.WithContext(ctx).Model(&entity.AccrualRequest{}).
+		Where("id = ? AND status IN ?", id, openStatuses).
+		Where("(locked_until IS NULL OR locked_until < ? OR assigned_to = ?)", now, reviewerID).
+		Updates(map[string]interface{}{
+			"assigned_to":  reviewerID,
+			"locked_until": until,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r repository) UnlockAccrualRequest(ctx context.Context, id string, reviewerID string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.AccrualRequest{}).
+		Where("id = ? AND assigned_to = ? AND locked_until IS NOT NULL", id, reviewerID).
+		Update("locked_until", nil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r repository) GetReviewerStats(ctx context.Context, slaHours int, from time.Time, to time.Time, now time.Time) ([]ReviewerStats, error) {
+	var decided []ReviewerStats
+	if err := r.db.WithContext(ctx).Model(&entity.AccrualRequest{}).
+		Select(`reviewer_id,
+			COUNT(*) AS reviewed,
+			COUNT(*) FILTER (WHERE reviewed_at > created_at + make_interval(hours => ?)) AS breached,
+			AVG(EXTRACT(EPOCH FROM reviewed_at - created_at) / 3600) AS avg_review_hours`, slaHours).
+		Where("status IN ? AND reviewer_id IS NOT NULL", decidedStatuses).
+		Where("reviewer_id <> ?", constants.SystemReviewerID). // Auto approvals take no time and would flatter the averages
+		Where("reviewed_at >= ? AND reviewed_at < ?", from, to).
+		Group("reviewer_id").
+		Scan(&decided).Error; err != nil {
+		return nil, err
+	}
+
+	var open []ReviewerStats
+	if err := r.db.WithContext(ctx).Model(&entity.AccrualRequest{}).
+		Select(`assigned_to AS reviewer_id,
+			COUNT(*) AS open,
+			COUNT(*) FILTER (WHERE created_at + make_interval(hours => ?) < ?) AS open_breached`, slaHours, now).
+		Where("status IN ? AND assigned_to IS NOT NULL", openStatuses).
+		Group("assigned_to").
+		Scan(&open).Error; err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*ReviewerStats, len(decided)+len(open))
+	for idx := range decided {
+		stats[decided[idx].ReviewerID] = &decided[idx]
+	}
+	for _, item := range open {
+		if existing, ok := stats[item.ReviewerID]; ok {
+			existing.Open = item.Open
+			existing.OpenBreached = item.OpenBreached
+			continue
+		}
+		stats[item.ReviewerID] = &item
+	}
+
+	result := make([]ReviewerStats, 0, len(stats))
+	for _, item := range stats {
+		result = append(result, *item)
+	}
+
+	// Worst offenders first
+	sort.Slice(result, func(i, j int) bool {
+		if breachedI, breachedJ := result[i].Breached+result[i].OpenBreached, result[j].Breached+result[j].OpenBreached; breachedI != breachedJ {
+			return breachedI > breachedJ
+		}
+		return result[i].ReviewerID < result[j].ReviewerID
+	})
+
+	return result, nil
+}
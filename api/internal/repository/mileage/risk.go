@@ -0,0 +1,53 @@
+package mileage
+
+import (
+	"context"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/google/uuid"
+)
+
+// Claims that were withdrawn or turned down do not count against the member
+var inactiveStatuses = []string{constants.RequestStatusCancelled, constants.RequestStatusRejected}
+
+func (r repository) CountOtherClaimants(ctx context.Context, customerID uuid.UUID, ticketIDs []string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.AccrualRequestSegment{}).
+		Joins("JOIN accrual_requests ON accrual_requests.id = accrual_request_segments.accrual_request_id").
+		Where("accrual_request_segments.ticket_id IN ?", ticketIDs).
+		Where("accrual_requests.customer_id <> ?", customerID).
+		Where("accrual_requests.status NOT IN ?", inactiveStatuses).
+		Distinct("accrual_requests.customer_id").
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r repository) CountSameDayFlights(ctx context.Context, customerID uuid.UUID, excludeRequestID uuid.UUID, ticketIDs []string, departureDates []time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.AccrualRequestSegment{}).
+		Joins("JOIN accrual_requests ON accrual_requests.id = accrual_request_segments.accrual_request_id").
+		Where("accrual_requests.customer_id = ? AND accrual_requests.id <> ?", customerID, excludeRequestID).
+		Where("accrual_requests.status NOT IN ?", inactiveStatuses).
+		Where("accrual_request_segments.ticket_id NOT IN ?", ticketIDs).
+		Where("accrual_request_segments.departure_date IN ?", departureDates).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r repository) CountClaimsSince(ctx context.Context, customerID uuid.UUID, excludeRequestID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.AccrualRequest{}).
+		Where("customer_id = ? AND id <> ?", customerID, excludeRequestID).
+		Where("status <> ?", constants.RequestStatusCancelled).
+		Where("created_at >= ?", since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
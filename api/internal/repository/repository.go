@@ -1,9 +1,16 @@
 package repository
 
 import (
+	"github.com/erwin-lovecraft/aegismiles/internal/repository/accrualrate"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository/airport"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository/autoapproval"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository/bookingclass"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository/campaign"
 	"github.com/erwin-lovecraft/aegismiles/internal/repository/customer"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository/exchangerate"
 	"github.com/erwin-lovecraft/aegismiles/internal/repository/membership"
 	"github.com/erwin-lovecraft/aegismiles/internal/repository/mileage"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository/repricing"
 	"gorm.io/gorm"
 )
 
@@ -11,21 +18,42 @@ type Repository interface {
 	Customer() customer.Repository
 	Mileage() mileage.Repository
 	Membership() membership.Repository
+	AccrualRate() accrualrate.Repository
+	Campaign() campaign.Repository
+	Airport() airport.Repository
+	BookingClass() bookingclass.Repository
+	ExchangeRate() exchangerate.Repository
+	Repricing() repricing.Repository
+	AutoApproval() autoapproval.Repository
 }
 
 type repository struct {
-	db         *gorm.DB
-	customer   customer.Repository
-	mileage    mileage.Repository
-	membership membership.Repository
+	db           *gorm.DB
+	customer     customer.Repository
+	mileage      mileage.Repository
+	membership   membership.Repository
+	accrualRate  accrualrate.Repository
+	campaign     campaign.Repository
+	airport      airport.Repository
+	bookingClass bookingclass.Repository
+	exchangeRate exchangerate.Repository
+	repricing    repricing.Repository
+	autoApproval autoapproval.Repository
 }
 
 func New(db *gorm.DB) Repository {
 	return repository{
-		db:         db,
-		customer:   customer.NewRepository(db),
-		mileage:    mileage.NewRepository(db),
-		membership: membership.NewRepository(db),
+		db:           db,
+		customer:     customer.NewRepository(db),
+		mileage:      mileage.NewRepository(db),
+		membership:   membership.NewRepository(db),
+		accrualRate:  accrualrate.NewRepository(db),
+		campaign:     campaign.NewRepository(db),
+		airport:      airport.NewRepository(db),
+		bookingClass: bookingclass.NewRepository(db),
+		exchangeRate: exchangerate.NewRepository(db),
+		repricing:    repricing.NewRepository(db),
+		autoApproval: autoapproval.NewRepository(db),
 	}
 }
 
@@ -40,3 +68,31 @@ func (r repository) Mileage() mileage.Repository {
 func (r repository) Membership() membership.Repository {
 	return r.membership
 }
+
+func (r repository) AccrualRate() accrualrate.Repository {
+	return r.accrualRate
+}
+
+func (r repository) Campaign() campaign.Repository {
+	return r.campaign
+}
+
+func (r repository) Airport() airport.Repository {
+	return r.airport
+}
+
+func (r repository) BookingClass() bookingclass.Repository {
+	return r.bookingClass
+}
+
+func (r repository) ExchangeRate() exchangerate.Repository {
+	return r.exchangeRate
+}
+
+func (r repository) Repricing() repricing.Repository {
+	return r.repricing
+}
+
+func (r repository) AutoApproval() autoapproval.Repository {
+	return r.autoApproval
+}
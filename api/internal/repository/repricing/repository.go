@@ -0,0 +1,53 @@
+package repricing
+
+import (
+	"context"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetRepricings(ctx context.Context, runID string, accrualRequestID string, page int, size int) ([]entity.AccrualRepricing, int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return repository{db: db}
+}
+
+func (r repository) GetRepricings(ctx context.Context, runID string, accrualRequestID string, page int, size int) ([]entity.AccrualRepricing, int64, error) {
+	qb := r.db.WithContext(ctx).Model(&entity.AccrualRepricing{})
+
+	if runID != "" {
+		qb = qb.Where("run_id = ?", runID)
+	}
+	if accrualRequestID != "" {
+		qb = qb.Where("accrual_request_id = ?", accrualRequestID)
+	}
+
+	var total int64
+	if err := qb.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	qb = qb.Order("created_at DESC")
+
+	offset, limit := pagination.ToSQLOffsetLimit(pagination.Pagination{Page: page, Size: size})
+	if offset > 0 {
+		qb = qb.Offset(offset)
+	}
+	if limit > 0 {
+		qb = qb.Limit(limit)
+	}
+
+	var repricings []entity.AccrualRepricing
+	if err := qb.Find(&repricings).Error; err != nil {
+		return nil, 0, err
+	}
+	return repricings, total, nil
+}
@@ -0,0 +1,286 @@
+package accrualrate
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetCharts(ctx context.Context, filter dto.AccrualRateChartFilter) ([]entity.AccrualRateChart, int64, error)
+
+	GetChart(ctx context.Context, id string) (entity.AccrualRateChart, error)
+
+	CreateChart(ctx context.Context, input dto.AccrualRateChartInput) (entity.AccrualRateChart, error)
+
+	UpdateChart(ctx context.Context, input dto.AccrualRateChartInput) (entity.AccrualRateChart, error)
+
+	DeleteChart(ctx context.Context, id string) error
+}
+
+type service struct {
+	repo repository.Repository
+}
+
+func New(repo repository.Repository) Service {
+	return service{
+		repo: repo,
+	}
+}
+
+func (s service) GetCharts(ctx context.Context, filter dto.AccrualRateChartFilter) ([]entity.AccrualRateChart, int64, error) {
+	return s.repo.AccrualRate().GetCharts(ctx, strings.ToUpper(filter.Carrier), filter.Page, filter.Size)
+}
+
+func (s service) GetChart(ctx context.Context, id string) (entity.AccrualRateChart, error) {
+	chart, err := s.repo.AccrualRate().GetChart(ctx, id)
+	if err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	if chart.ID == uuid.Nil {
+		return entity.AccrualRateChart{}, errors.New("accrual rate chart does not exists")
+	}
+
+	return chart, nil
+}
+
+func (s service) CreateChart(ctx context.Context, input dto.AccrualRateChartInput) (entity.AccrualRateChart, error) {
+	chart, err := toChartEntity(input)
+	if err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	if err := s.checkBookingClasses(ctx, chart); err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	if err := s.checkOverlap(ctx, chart); err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	// Generate ID here so the created chart can be returned to the caller
+	id, err := generator.AccrualRateChartID.Generate()
+	if err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+	chart.ID = id
+
+	if err := s.repo.AccrualRate().SaveChart(ctx, chart); err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	return s.GetChart(ctx, chart.ID.String())
+}
+
+func (s service) UpdateChart(ctx context.Context, input dto.AccrualRateChartInput) (entity.AccrualRateChart, error) {
+	existed, err := s.GetChart(ctx, input.ID)
+	if err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	chart, err := toChartEntity(input)
+	if err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+	chart.ID = existed.ID
+	chart.CreatedAt = existed.CreatedAt
+
+	// A chart that already went live has priced requests, so only its end date may still move
+	today := startOfDay(time.Now().UTC())
+	if !existed.ValidFrom.After(today) {
+		if !chart.ValidFrom.Equal(existed.ValidFrom) || !sameScope(chart, existed) || !sameRates(chart.Rates, existed.Rates) || !maps.Equal(chart.TierBonusUplifts, existed.TierBonusUplifts) || !sameEarning(chart, existed) {
+			return entity.AccrualRateChart{}, errors.New("accrual rate chart is already in force")
+		}
+		if chart.ValidTo != nil && chart.ValidTo.Before(today) {
+			return entity.AccrualRateChart{}, errors.New("accrual rate chart cannot end in the past")
+		}
+		chart.Rates = existed.Rates
+	}
+
+	if err := s.checkBookingClasses(ctx, chart); err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	if err := s.checkOverlap(ctx, chart); err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	if err := s.repo.AccrualRate().SaveChart(ctx, chart); err != nil {
+		return entity.AccrualRateChart{}, err
+	}
+
+	return s.GetChart(ctx, chart.ID.String())
+}
+
+func (s service) DeleteChart(ctx context.Context, id string) error {
+	existed, err := s.GetChart(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !existed.ValidFrom.After(startOfDay(time.Now().UTC())) {
+		return errors.New("accrual rate chart is already in force")
+	}
+
+	return s.repo.AccrualRate().DeleteChart(ctx, id)
+}
+
+// checkBookingClasses makes sure every rate is for a class in the booking class catalogue
+func (s service) checkBookingClasses(ctx context.Context, chart entity.AccrualRateChart) error {
+	classes, err := s.repo.BookingClass().GetBookingClasses(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		known[class.Code] = true
+	}
+
+	for _, rate := range chart.Rates {
+		if !known[rate.BookingClass] {
+			return errors.New("invalid accrual rate chart")
+		}
+	}
+
+	return nil
+}
+
+func (s service) checkOverlap(ctx context.Context, chart entity.AccrualRateChart) error {
+	excludeID := ""
+	if chart.ID != uuid.Nil {
+		excludeID = chart.ID.String()
+	}
+
+	overlapped, err := s.repo.AccrualRate().GetOverlappingCharts(ctx, chart.Carrier, chart.OperatingCarrier, chart.ValidFrom, chart.ValidTo, excludeID)
+	if err != nil {
+		return err
+	}
+
+	if len(overlapped) > 0 {
+		return errors.New("accrual rate chart overlaps an existing chart")
+	}
+
+	return nil
+}
+
+func toChartEntity(input dto.AccrualRateChartInput) (entity.AccrualRateChart, error) {
+	chart := entity.AccrualRateChart{
+		Name:             input.Name,
+		Carrier:          strings.ToUpper(input.Carrier),
+		ValidFrom:        startOfDay(input.ValidFrom),
+		EarningMode:      input.EarningMode,
+		TierBonusUplifts: input.TierBonusUplifts,
+		RevenueRates:     input.RevenueRates,
+	}
+	if chart.EarningMode == "" {
+		chart.EarningMode = constants.EarningModeDistance
+	}
+	if chart.TierBonusUplifts == nil {
+		chart.TierBonusUplifts = map[string]float64{}
+	}
+	if chart.RevenueRates == nil {
+		chart.RevenueRates = map[string]float64{}
+	}
+
+	switch chart.EarningMode {
+	case constants.EarningModeDistance:
+		if len(input.Rates) == 0 {
+			return entity.AccrualRateChart{}, errors.New("invalid accrual rate chart")
+		}
+	case constants.EarningModeRevenue:
+		if input.ProgramCurrency == "" || len(input.RevenueRates) == 0 {
+			return entity.AccrualRateChart{}, errors.New("invalid accrual rate chart")
+		}
+		programCurrency := strings.ToUpper(input.ProgramCurrency)
+		chart.ProgramCurrency = &programCurrency
+	}
+
+	if input.OperatingCarrier != "" {
+		operatingCarrier := strings.ToUpper(input.OperatingCarrier)
+		chart.OperatingCarrier = &operatingCarrier
+	}
+
+	if input.ValidTo != nil {
+		validTo := startOfDay(*input.ValidTo)
+		if validTo.Before(chart.ValidFrom) {
+			return entity.AccrualRateChart{}, errors.New("invalid accrual rate chart")
+		}
+		chart.ValidTo = &validTo
+	}
+
+	seen := make(map[string]bool, len(input.Rates))
+	for _, rate := range input.Rates {
+		bookingClass := strings.ToUpper(rate.BookingClass)
+		if seen[bookingClass] || len(rate.BonusMiles) != len(rate.BonusValues) {
+			return entity.AccrualRateChart{}, errors.New("invalid accrual rate chart")
+		}
+		seen[bookingClass] = true
+
+		chart.Rates = append(chart.Rates, entity.AccrualRate{
+			BookingClass:   bookingClass,
+			QualifyingRate: rate.QualifyingRate,
+			BonusTiers: entity.BonusTiers{
+				Miles:  rate.BonusMiles,
+				Values: rate.BonusValues,
+			},
+		})
+	}
+
+	return chart, nil
+}
+
+func sameScope(a, b entity.AccrualRateChart) bool {
+	if a.Carrier != b.Carrier {
+		return false
+	}
+	if a.OperatingCarrier == nil || b.OperatingCarrier == nil {
+		return a.OperatingCarrier == b.OperatingCarrier
+	}
+	return *a.OperatingCarrier == *b.OperatingCarrier
+}
+
+func sameEarning(a, b entity.AccrualRateChart) bool {
+	if a.EarningMode != b.EarningMode || !maps.Equal(a.RevenueRates, b.RevenueRates) {
+		return false
+	}
+	if a.ProgramCurrency == nil || b.ProgramCurrency == nil {
+		return a.ProgramCurrency == b.ProgramCurrency
+	}
+	return *a.ProgramCurrency == *b.ProgramCurrency
+}
+
+func sameRates(a, b []entity.AccrualRate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	byClass := make(map[string]entity.AccrualRate, len(b))
+	for _, rate := range b {
+		byClass[rate.BookingClass] = rate
+	}
+
+	for _, rate := range a {
+		other, ok := byClass[rate.BookingClass]
+		if !ok || other.QualifyingRate != rate.QualifyingRate || !slices.Equal(other.BonusTiers.Miles, rate.BonusTiers.Miles) || !slices.Equal(other.BonusTiers.Values, rate.BonusTiers.Values) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
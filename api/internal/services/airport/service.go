@@ -0,0 +1,35 @@
+package airport
+
+import (
+	"context"
+	"strings"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+)
+
+const defaultSearchSize = 10
+
+type Service interface {
+	SearchAirports(ctx context.Context, filter dto.AirportFilter) ([]entity.Airport, error)
+}
+
+type service struct {
+	repo repository.Repository
+}
+
+func New(repo repository.Repository) Service {
+	return service{
+		repo: repo,
+	}
+}
+
+func (s service) SearchAirports(ctx context.Context, filter dto.AirportFilter) ([]entity.Airport, error) {
+	size := filter.Size
+	if size <= 0 {
+		size = defaultSearchSize
+	}
+
+	return s.repo.Airport().SearchAirports(ctx, strings.TrimSpace(filter.Query), size)
+}
@@ -0,0 +1,26 @@
+package bookingclass
+
+import (
+	"context"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+)
+
+type Service interface {
+	GetBookingClasses(ctx context.Context) ([]entity.BookingClass, error)
+}
+
+type service struct {
+	repo repository.Repository
+}
+
+func New(repo repository.Repository) Service {
+	return service{
+		repo: repo,
+	}
+}
+
+func (s service) GetBookingClasses(ctx context.Context) ([]entity.BookingClass, error) {
+	return s.repo.BookingClass().GetBookingClasses(ctx)
+}
@@ -0,0 +1,353 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+	"github.com/google/uuid"
+	"github.com/viebiz/lit/iam"
+)
+
+type Service interface {
+	GetCampaigns(ctx context.Context, filter dto.CampaignFilter) ([]entity.Campaign, int64, error)
+
+	GetCampaign(ctx context.Context, id string) (entity.Campaign, error)
+
+	CreateCampaign(ctx context.Context, input dto.CampaignInput) (entity.Campaign, error)
+
+	UpdateCampaign(ctx context.Context, input dto.CampaignInput) (entity.Campaign, error)
+
+	ScheduleCampaign(ctx context.Context, id string) error
+
+	PauseCampaign(ctx context.Context, id string) error
+
+	GetCampaignReport(ctx context.Context, id string) (dto.CampaignReport, error)
+
+	GetRunningCampaigns(ctx context.Context) ([]entity.Campaign, error)
+
+	OptIn(ctx context.Context, id string) error
+
+	// Evaluate returns the award of every running campaign a segment of the accrual request qualifies for
+	Evaluate(ctx context.Context, req entity.AccrualRequest, segment entity.AccrualRequestSegment) ([]dto.CampaignAward, error)
+}
+
+type service struct {
+	repo     repository.Repository
+	rounding miles.Rounding
+}
+
+func New(repo repository.Repository, rounding miles.Rounding) Service {
+	return service{
+		repo:     repo,
+		rounding: rounding,
+	}
+}
+
+func (s service) GetCampaigns(ctx context.Context, filter dto.CampaignFilter) ([]entity.Campaign, int64, error) {
+	return s.repo.Campaign().GetCampaigns(ctx, filter.Status, filter.Page, filter.Size)
+}
+
+func (s service) GetCampaign(ctx context.Context, id string) (entity.Campaign, error) {
+	campaign, err := s.repo.Campaign().GetCampaign(ctx, id)
+	if err != nil {
+		return entity.Campaign{}, err
+	}
+
+	if campaign.ID == uuid.Nil {
+		return entity.Campaign{}, errors.New("campaign does not exists")
+	}
+
+	return campaign, nil
+}
+
+func (s service) CreateCampaign(ctx context.Context, input dto.CampaignInput) (entity.Campaign, error) {
+	campaign, err := toCampaignEntity(input)
+	if err != nil {
+		return entity.Campaign{}, err
+	}
+	campaign.Status = constants.CampaignStatusDraft
+
+	// Generate ID here so the created campaign can be returned to the caller
+	id, err := generator.CampaignID.Generate()
+	if err != nil {
+		return entity.Campaign{}, err
+	}
+	campaign.ID = id
+
+	if err := s.repo.Campaign().SaveCampaign(ctx, campaign); err != nil {
+		return entity.Campaign{}, err
+	}
+
+	return s.GetCampaign(ctx, campaign.ID.String())
+}
+
+func (s service) UpdateCampaign(ctx context.Context, input dto.CampaignInput) (entity.Campaign, error) {
+	existed, err := s.GetCampaign(ctx, input.ID)
+	if err != nil {
+		return entity.Campaign{}, err
+	}
+
+	// Rules of a scheduled campaign may already have paid out, so it has to be paused first
+	if existed.Status == constants.CampaignStatusScheduled {
+		return entity.Campaign{}, errors.New("invalid status")
+	}
+
+	campaign, err := toCampaignEntity(input)
+	if err != nil {
+		return entity.Campaign{}, err
+	}
+	campaign.ID = existed.ID
+	campaign.Status = existed.Status
+	campaign.CreatedAt = existed.CreatedAt
+
+	if err := s.repo.Campaign().SaveCampaign(ctx, campaign); err != nil {
+		return entity.Campaign{}, err
+	}
+
+	return s.GetCampaign(ctx, campaign.ID.String())
+}
+
+func (s service) ScheduleCampaign(ctx context.Context, id string) error {
+	existed, err := s.GetCampaign(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if existed.Status == constants.CampaignStatusScheduled {
+		return errors.New("invalid status")
+	}
+
+	if existed.EndsAt != nil && existed.EndsAt.Before(time.Now().UTC()) {
+		return errors.New("campaign has already ended")
+	}
+
+	existed.Status = constants.CampaignStatusScheduled
+	return s.repo.Campaign().SaveCampaign(ctx, existed)
+}
+
+func (s service) PauseCampaign(ctx context.Context, id string) error {
+	existed, err := s.GetCampaign(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if existed.Status != constants.CampaignStatusScheduled {
+		return errors.New("invalid status")
+	}
+
+	existed.Status = constants.CampaignStatusPaused
+	return s.repo.Campaign().SaveCampaign(ctx, existed)
+}
+
+func (s service) GetCampaignReport(ctx context.Context, id string) (dto.CampaignReport, error) {
+	campaign, err := s.GetCampaign(ctx, id)
+	if err != nil {
+		return dto.CampaignReport{}, err
+	}
+
+	optIns, err := s.repo.Campaign().CountOptIns(ctx, id)
+	if err != nil {
+		return dto.CampaignReport{}, err
+	}
+
+	awards, customers, bonusMiles, err := s.repo.Campaign().GetAwardStats(ctx, id)
+	if err != nil {
+		return dto.CampaignReport{}, err
+	}
+
+	return dto.CampaignReport{
+		CampaignID: campaign.ID,
+		OptIns:     optIns,
+		Awards:     awards,
+		Customers:  customers,
+		BonusMiles: bonusMiles,
+	}, nil
+}
+
+func (s service) GetRunningCampaigns(ctx context.Context) ([]entity.Campaign, error) {
+	return s.repo.Campaign().GetRunningCampaigns(ctx, time.Now().UTC())
+}
+
+func (s service) OptIn(ctx context.Context, id string) error {
+	userProfile := iam.GetUserProfileFromContext(ctx)
+
+	customer, err := s.repo.Customer().GetByUserID(ctx, userProfile.ID())
+	if err != nil {
+		return err
+	}
+
+	if customer.ID == uuid.Nil {
+		return errors.New("user not found")
+	}
+
+	campaign, err := s.GetCampaign(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if campaign.Status != constants.CampaignStatusScheduled || !campaign.RequiresOptIn ||
+		(campaign.EndsAt != nil && campaign.EndsAt.Before(time.Now().UTC())) {
+		return errors.New("campaign is not open for opt-in")
+	}
+
+	return s.repo.Campaign().SaveOptIn(ctx, entity.CampaignOptIn{
+		CampaignID: campaign.ID,
+		CustomerID: customer.ID,
+	})
+}
+
+func (s service) Evaluate(ctx context.Context, req entity.AccrualRequest, segment entity.AccrualRequestSegment) ([]dto.CampaignAward, error) {
+	// A claim earns the promotions that were running when it was filed
+	submittedAt := req.CreatedAt
+	if submittedAt.IsZero() {
+		submittedAt = time.Now().UTC()
+	}
+
+	campaigns, err := s.repo.Campaign().GetRunningCampaigns(ctx, submittedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var optInRequired []uuid.UUID
+	for _, campaign := range campaigns {
+		if campaign.RequiresOptIn {
+			optInRequired = append(optInRequired, campaign.ID)
+		}
+	}
+
+	var optedIn []uuid.UUID
+	if req.CustomerID != uuid.Nil {
+		optedIn, err = s.repo.Campaign().GetOptedInCampaignIDs(ctx, req.CustomerID, optInRequired)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var awards []dto.CampaignAward
+	for _, campaign := range campaigns {
+		if campaign.RequiresOptIn && !slices.Contains(optedIn, campaign.ID) {
+			continue
+		}
+		if !matches(campaign, req.MemberTier, segment) {
+			continue
+		}
+
+		bonusMiles := s.rounding.Apply(campaign.BonusRate, segment.QualifyingMiles) + campaign.FixedMiles
+		if bonusMiles <= 0 {
+			continue
+		}
+
+		awards = append(awards, dto.CampaignAward{
+			CampaignID:   campaign.ID,
+			CampaignName: campaign.Name,
+			BonusMiles:   bonusMiles,
+		})
+	}
+
+	return awards, nil
+}
+
+func matches(campaign entity.Campaign, memberTier string, segment entity.AccrualRequestSegment) bool {
+	if !matchesRoute(campaign, segment.FromCode, segment.ToCode) {
+		return false
+	}
+
+	if len(campaign.BookingClasses) > 0 && !slices.Contains(campaign.BookingClasses, segment.BookingClass) {
+		return false
+	}
+
+	if len(campaign.MemberTiers) > 0 && !slices.Contains(campaign.MemberTiers, memberTier) {
+		return false
+	}
+
+	departure := startOfDay(segment.DepartureDate)
+	if campaign.DepartureFrom != nil && departure.Before(startOfDay(*campaign.DepartureFrom)) {
+		return false
+	}
+	if campaign.DepartureTo != nil && departure.After(startOfDay(*campaign.DepartureTo)) {
+		return false
+	}
+
+	return true
+}
+
+func matchesRoute(campaign entity.Campaign, fromCode, toCode string) bool {
+	match := func(from, to string) bool {
+		return (campaign.FromCode == nil || strings.EqualFold(*campaign.FromCode, from)) &&
+			(campaign.ToCode == nil || strings.EqualFold(*campaign.ToCode, to))
+	}
+
+	return match(fromCode, toCode) || (campaign.Bidirectional && match(toCode, fromCode))
+}
+
+func toCampaignEntity(input dto.CampaignInput) (entity.Campaign, error) {
+	if input.EndsAt != nil && input.EndsAt.Before(input.StartsAt) {
+		return entity.Campaign{}, errors.New("invalid campaign")
+	}
+
+	if input.DepartureFrom != nil && input.DepartureTo != nil && input.DepartureTo.Before(*input.DepartureFrom) {
+		return entity.Campaign{}, errors.New("invalid campaign")
+	}
+
+	if input.BonusRate == 0 && input.FixedMiles == 0 {
+		return entity.Campaign{}, errors.New("invalid campaign")
+	}
+
+	campaign := entity.Campaign{
+		Name:           input.Name,
+		Description:    input.Description,
+		StartsAt:       input.StartsAt.UTC(),
+		Bidirectional:  input.Bidirectional,
+		BookingClasses: input.BookingClasses,
+		MemberTiers:    input.MemberTiers,
+		RequiresOptIn:  input.RequiresOptIn,
+		BonusRate:      input.BonusRate,
+		FixedMiles:     input.FixedMiles,
+	}
+	if campaign.BookingClasses == nil {
+		campaign.BookingClasses = []string{}
+	}
+	if campaign.MemberTiers == nil {
+		campaign.MemberTiers = []string{}
+	}
+
+	if input.EndsAt != nil {
+		endsAt := input.EndsAt.UTC()
+		campaign.EndsAt = &endsAt
+	}
+
+	if input.FromCode != "" {
+		fromCode := strings.ToUpper(input.FromCode)
+		campaign.FromCode = &fromCode
+	}
+
+	if input.ToCode != "" {
+		toCode := strings.ToUpper(input.ToCode)
+		campaign.ToCode = &toCode
+	}
+
+	if input.DepartureFrom != nil {
+		departureFrom := startOfDay(*input.DepartureFrom)
+		campaign.DepartureFrom = &departureFrom
+	}
+
+	if input.DepartureTo != nil {
+		departureTo := startOfDay(*input.DepartureTo)
+		campaign.DepartureTo = &departureTo
+	}
+
+	return campaign, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -8,20 +8,23 @@ import (
 	"github.com/erwin-lovecraft/aegismiles/internal/gateway/auth0"
 	"github.com/erwin-lovecraft/aegismiles/internal/gateway/sessionm"
 	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
 	"github.com/erwin-lovecraft/aegismiles/internal/repository"
 	"github.com/google/uuid"
 )
 
 type v2service struct {
 	cfg         config.SessionMConfig
+	rounding    miles.Rounding
 	repo        repository.Repository
 	auth0Svc    auth0.Client
 	sessionmSvc sessionm.Client
 }
 
-func NewV2(cfg config.SessionMConfig, repo repository.Repository, authGwy auth0.Client, sessionmGwy sessionm.Client) Service {
+func NewV2(cfg config.SessionMConfig, rounding miles.Rounding, repo repository.Repository, authGwy auth0.Client, sessionmGwy sessionm.Client) Service {
 	return v2service{
 		cfg:         cfg,
+		rounding:    rounding,
 		repo:        repo,
 		auth0Svc:    authGwy,
 		sessionmSvc: sessionmGwy,
@@ -67,7 +70,7 @@ func (s v2service) GetCustomer(ctx context.Context, userID string) (entity.Custo
 		}
 	}
 
-	rs := convertCustomerEntity(s.cfg, customer)
+	rs := convertCustomerEntity(s.cfg, s.rounding, customer)
 	if err := s.repo.Customer().Save(ctx, rs); err != nil {
 		return entity.Customer{}, err
 	}
@@ -75,44 +78,26 @@ func (s v2service) GetCustomer(ctx context.Context, userID string) (entity.Custo
 	return rs, nil
 }
 
-func convertMemberTier(tier string) string {
-	tierNameMap := map[string]string{
-		"[Team-1] Silver":        "silver",
-		"[Team-1] Titan":         "titan",
-		"[Team-1] Gold":          "gold",
-		"[Team-1] Platinum":      "platinum",
-		"[Team-1] Million Miler": "million_miler",
-	}
-
-	if tierName, exists := tierNameMap[tier]; exists {
-		return tierName
-	}
-
-	return "register"
-}
-
-func convertCustomerEntity(cfg config.SessionMConfig, customer dto.SessionMUserProfile) entity.Customer {
+// convertCustomerEntity settles the point balances SessionM reports with the accrual rounding policy
+func convertCustomerEntity(cfg config.SessionMConfig, rounding miles.Rounding, customer dto.SessionMUserProfile) entity.Customer {
 	rs := entity.Customer{
 		ID:                   customer.ID,
-		QualifyingMilesTotal: customer.TierPoints,
-		BonusMilesTotal:      customer.TestPoints,
+		QualifyingMilesTotal: rounding.Round(customer.TierPoints),
 		Auth0UserID:          customer.ExternalID,
 		Email:                customer.Email,
 		FirstName:            customer.FirstName,
 		LastName:             customer.LastName,
-		MemberTier:           "register",
+		MemberTier:           sessionm.MemberTier(customer, cfg.TierSystemID),
 	}
 	// TODO: Add phone
 
-	for _, tierLevel := range customer.TierDetails.TierLevels {
-		if tierLevel.TierSystemID == cfg.TierSystemID {
-			rs.MemberTier = convertMemberTier(tierLevel.TierOverview.Name)
-		}
-	}
-
+	// Bonus and tier bonus miles are deposited to their own account, the same one reversals check
 	for _, detail := range customer.TierDetails.PointAccountBalances.Details {
-		if detail.PointAccountID == cfg.PointAccountID {
-			rs.QualifyingMilesTotal = detail.AvailableBalance
+		switch {
+		case detail.PointAccountID == cfg.PointAccountID:
+			rs.QualifyingMilesTotal = rounding.Round(detail.AvailableBalance)
+		case cfg.BonusAccountID != "" && detail.PointAccountID == cfg.BonusAccountID:
+			rs.BonusMilesTotal = rounding.Round(detail.AvailableBalance)
 		}
 	}
 
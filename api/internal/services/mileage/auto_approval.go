@@ -0,0 +1,150 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/monitoring"
+)
+
+// approvalMode tells who asked for an approval, only a reviewer approving a single request may approve a high risk claim
+type approvalMode int
+
+const (
+	approvalManual approvalMode = iota
+	approvalBulk
+	approvalAuto
+)
+
+// autoApprove approves a freshly submitted or verified request when it matches the auto approval policy. Anything the
+// policy does not cover is left in the queue, a failure here never fails the submission.
+func (s service) autoApprove(ctx context.Context, req entity.AccrualRequest) {
+	policy, err := s.repo.AutoApproval().GetPolicy(ctx)
+	if err != nil {
+		monitoring.FromContext(ctx).Errorf(err, "[autoApprove] failed to load policy")
+		return
+	}
+
+	if !s.matchesPolicy(policy, req) {
+		return
+	}
+
+	systemCtx := iam.SetUserProfileInContext(ctx, iam.NewUserProfile(constants.SystemReviewerID, []string{constants.UserRoleAdmin}, nil))
+	if err := s.approveRequest(systemCtx, req.ID.String(), approvalAuto); err != nil {
+		monitoring.FromContext(ctx).Errorf(err, "[autoApprove] failed to approve accrual request %s", req.ID)
+		return
+	}
+
+	monitoring.FromContext(ctx).Infof("[autoApprove] approved accrual request %s", req.ID)
+}
+
+func (s service) matchesPolicy(policy entity.AutoApprovalPolicy, req entity.AccrualRequest) bool {
+	if !policy.Enabled {
+		return false
+	}
+
+	// Overridden and high value claims always get a human
+	if req.LateClaimApprovedBy != nil || s.needsSecondApproval(req) || s.risk.IsHighRisk(req.RiskScore) {
+		return false
+	}
+
+	if policy.RequireVerifiedBoardingPass && req.BoardingPassVerifiedAt == nil {
+		return false
+	}
+
+	if policy.RequirePublishedRoute {
+		for _, segment := range req.Segments {
+			if segment.DistanceSource != constants.DistanceSourcePublished {
+				return false
+			}
+		}
+	}
+
+	if req.RiskScore > policy.MaxRiskScore || req.QualifyingMiles+req.BonusMiles+req.TierBonusMiles > policy.MaxMiles {
+		return false
+	}
+
+	return len(policy.MemberTiers) == 0 || slices.Contains(policy.MemberTiers, req.MemberTier)
+}
+
+func (s service) GetAutoApprovalPolicy(ctx context.Context) (entity.AutoApprovalPolicy, error) {
+	return s.repo.AutoApproval().GetPolicy(ctx)
+}
+
+func (s service) UpdateAutoApprovalPolicy(ctx context.Context, input dto.AutoApprovalPolicyInput) (entity.AutoApprovalPolicy, error) {
+	userID := iam.GetUserProfileFromContext(ctx).ID()
+	policy := entity.AutoApprovalPolicy{
+		Enabled:                     input.Enabled,
+		RequireVerifiedBoardingPass: input.RequireVerifiedBoardingPass,
+		RequirePublishedRoute:       input.RequirePublishedRoute,
+		MaxRiskScore:                input.MaxRiskScore,
+		MaxMiles:                    input.MaxMiles,
+		MemberTiers:                 input.MemberTiers,
+		UpdatedBy:                   &userID,
+		UpdatedAt:                   time.Now().UTC(),
+	}
+	if policy.MemberTiers == nil {
+		policy.MemberTiers = []string{}
+	}
+
+	if err := s.repo.AutoApproval().SavePolicy(ctx, policy); err != nil {
+		return entity.AutoApprovalPolicy{}, err
+	}
+
+	return s.repo.AutoApproval().GetPolicy(ctx)
+}
+
+func (s service) DisableAutoApproval(ctx context.Context) error {
+	policy, err := s.repo.AutoApproval().GetPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	userID := iam.GetUserProfileFromContext(ctx).ID()
+	policy.Enabled = false
+	policy.UpdatedBy = &userID
+	policy.UpdatedAt = time.Now().UTC()
+
+	return s.repo.AutoApproval().SavePolicy(ctx, policy)
+}
+
+func (s service) GetAutoApprovalReport(ctx context.Context, filter dto.AutoApprovalReportFilter) (dto.AutoApprovalReport, error) {
+	to := filter.To
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+
+	from := filter.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+
+	if !from.Before(to) {
+		return dto.AutoApprovalReport{}, errors.New("invalid report period")
+	}
+
+	policy, err := s.repo.AutoApproval().GetPolicy(ctx)
+	if err != nil {
+		return dto.AutoApprovalReport{}, err
+	}
+
+	approved, reversed, credited, err := s.repo.AutoApproval().GetStats(ctx, from, to)
+	if err != nil {
+		return dto.AutoApprovalReport{}, err
+	}
+
+	return dto.AutoApprovalReport{
+		From:          from,
+		To:            to,
+		Enabled:       policy.Enabled,
+		Approved:      approved,
+		Reversed:      reversed,
+		CreditedMiles: credited,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package mileage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/config"
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/risk"
+)
+
+func TestMatchesPolicy(t *testing.T) {
+	verifiedAt := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	admin := "admin"
+	policy := entity.AutoApprovalPolicy{
+		Enabled:                     true,
+		RequireVerifiedBoardingPass: true,
+		RequirePublishedRoute:       true,
+		MaxRiskScore:                20,
+		MaxMiles:                    5000,
+		MemberTiers:                 []string{constants.MemberTierRegister, constants.MemberTierSilver},
+	}
+	routine := entity.AccrualRequest{
+		MemberTier:             constants.MemberTierSilver,
+		QualifyingMiles:        1000,
+		BonusMiles:             500,
+		TierBonusMiles:         100,
+		RiskScore:              10,
+		BoardingPassImageURL:   "https://example.com/boarding-pass.png",
+		BoardingPassVerifiedAt: &verifiedAt,
+		Segments:               []entity.AccrualRequestSegment{{DistanceSource: constants.DistanceSourcePublished}},
+	}
+
+	tcs := map[string]struct {
+		givenPolicy  func(entity.AutoApprovalPolicy) entity.AutoApprovalPolicy
+		givenRequest func(entity.AccrualRequest) entity.AccrualRequest
+		expResult    bool
+	}{
+		"routine claim": {
+			expResult: true,
+		},
+		"policy disabled": {
+			givenPolicy: func(p entity.AutoApprovalPolicy) entity.AutoApprovalPolicy { p.Enabled = false; return p },
+		},
+		"boarding pass uploaded but not verified": {
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.BoardingPassVerifiedAt = nil; return r },
+		},
+		"unverified boarding pass allowed by the policy": {
+			givenPolicy: func(p entity.AutoApprovalPolicy) entity.AutoApprovalPolicy {
+				p.RequireVerifiedBoardingPass = false
+				return p
+			},
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.BoardingPassVerifiedAt = nil; return r },
+			expResult:    true,
+		},
+		"computed distance": {
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest {
+				r.Segments = []entity.AccrualRequestSegment{{DistanceSource: constants.DistanceSourcePublished}, {DistanceSource: constants.DistanceSourceComputed}}
+				return r
+			},
+		},
+		"computed distance allowed by the policy": {
+			givenPolicy: func(p entity.AutoApprovalPolicy) entity.AutoApprovalPolicy { p.RequirePublishedRoute = false; return p },
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest {
+				r.Segments = []entity.AccrualRequestSegment{{DistanceSource: constants.DistanceSourceComputed}}
+				return r
+			},
+			expResult: true,
+		},
+		"risk score above the policy": {
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.RiskScore = 21; return r },
+		},
+		"high risk even when the policy allows the score": {
+			givenPolicy:  func(p entity.AutoApprovalPolicy) entity.AutoApprovalPolicy { p.MaxRiskScore = 100; return p },
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.RiskScore = 60; return r },
+		},
+		"miles above the policy": {
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.TierBonusMiles = 3501; return r },
+		},
+		"miles at the policy": {
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.TierBonusMiles = 3500; return r },
+			expResult:    true,
+		},
+		"high value claim needs a second admin": {
+			givenPolicy:  func(p entity.AutoApprovalPolicy) entity.AutoApprovalPolicy { p.MaxMiles = 100000; return p },
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.QualifyingMiles = 20000; return r },
+		},
+		"late claim override": {
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.LateClaimApprovedBy = &admin; return r },
+		},
+		"tier not covered": {
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.MemberTier = constants.MemberTierGold; return r },
+		},
+		"any tier": {
+			givenPolicy:  func(p entity.AutoApprovalPolicy) entity.AutoApprovalPolicy { p.MemberTiers = nil; return p },
+			givenRequest: func(r entity.AccrualRequest) entity.AccrualRequest { r.MemberTier = constants.MemberTierGold; return r },
+			expResult:    true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			givenPolicy, givenRequest := policy, routine
+			if tc.givenPolicy != nil {
+				givenPolicy = tc.givenPolicy(givenPolicy)
+			}
+			if tc.givenRequest != nil {
+				givenRequest = tc.givenRequest(givenRequest)
+			}
+			svc := service{risk: risk.New(config.RiskConfig{}, nil)}
+
+			if got := svc.matchesPolicy(givenPolicy, givenRequest); got != tc.expResult {
+				t.Fatalf("expected %t, got %t", tc.expResult, got)
+			}
+		})
+	}
+}
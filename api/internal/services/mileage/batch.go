@@ -0,0 +1,78 @@
+package mileage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+)
+
+const defaultBatchConcurrency = 4
+
+func (s service) BatchReviewAccrualRequests(ctx context.Context, input dto.BatchReviewInput) []dto.BatchReviewResult {
+	concurrency := s.cfg.BatchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	// The same request listed twice would be reviewed twice in parallel
+	ids := make([]string, 0, len(input.IDs))
+	seen := make(map[string]bool, len(input.IDs))
+	for _, id := range input.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	results := make([]dto.BatchReviewResult, len(ids))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for idx, id := range ids {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			var err error
+			if input.Action == "reject" {
+				err = s.RejectAccrualRequest(ctx, id, dto.Rejection{
+					ReasonCode:     input.ReasonCode,
+					Note:           input.Note,
+					RejectedReason: input.RejectedReason,
+				})
+			} else {
+				err = s.approveRequest(ctx, id, approvalBulk)
+			}
+			results[idx] = batchResult(id, err)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func batchResult(id string, err error) dto.BatchReviewResult {
+	result := dto.BatchReviewResult{ID: id, Result: "ok"}
+	if err == nil {
+		return result
+	}
+
+	result.Error = err.Error()
+	switch {
+	case err.Error() == "invalid status":
+		result.Result = "invalid_status"
+	case err.Error() == "accrual request does not exists":
+		result.Result = "not_found"
+	case err.Error() == "accrual request is locked by another reviewer":
+		result.Result = "locked"
+	case err.Error() == "accrual request was changed by someone else":
+		result.Result = "conflict"
+	case err.Error() == "high risk request must be approved individually":
+		result.Result = "high_risk"
+	default:
+		result.Result = "error"
+	}
+	return result
+}
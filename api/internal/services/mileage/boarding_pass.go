@@ -0,0 +1,40 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/viebiz/lit/iam"
+)
+
+// VerifyBoardingPass records that the signed in reviewer checked the uploaded boarding pass against the claim. A
+// policy requiring a verified boarding pass is evaluated again afterwards, a request still locked by a reviewer stays
+// in the queue for them to decide.
+func (s service) VerifyBoardingPass(ctx context.Context, reqID string) error {
+	existedRequest, err := s.getReviewableRequest(ctx, reqID)
+	if err != nil {
+		return err
+	}
+
+	if existedRequest.BoardingPassImageURL == "" {
+		return errors.New("boarding pass is missing")
+	}
+
+	userID := iam.GetUserProfileFromContext(ctx).ID()
+	now := time.Now().UTC()
+	existedRequest.BoardingPassVerifiedBy = &userID
+	existedRequest.BoardingPassVerifiedAt = &now
+
+	if err := s.updateRequest(ctx, existedRequest, existedRequest.Status, userID); err != nil {
+		return err
+	}
+
+	verifiedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, reqID)
+	if err != nil {
+		return err
+	}
+	s.autoApprove(ctx, verifiedRequest)
+
+	return nil
+}
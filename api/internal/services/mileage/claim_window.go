@@ -0,0 +1,55 @@
+package mileage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+)
+
+const (
+	defaultClaimMaxAgeDays    = 180
+	defaultClaimMinDelayHours = 24
+)
+
+type claimWindowOverride struct {
+	Reason string
+	By     string
+}
+
+// checkClaimWindow makes sure every flight has departed a while ago but not too long ago.
+// Departure dates carry no time of day, so a flight counts as departed at the start of its date
+func (s service) checkClaimWindow(segments []entity.AccrualRequestSegment, now time.Time, lateClaimAccepted bool) error {
+	maxAgeDays := s.claimMaxAgeDays()
+
+	minDelayHours := s.cfg.ClaimMinDelayHours
+	if minDelayHours <= 0 {
+		minDelayHours = defaultClaimMinDelayHours
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, segment := range segments {
+		departure := time.Date(segment.DepartureDate.Year(), segment.DepartureDate.Month(), segment.DepartureDate.Day(), 0, 0, 0, 0, time.UTC)
+
+		if departure.After(today) {
+			return errors.New("departure date is in the future")
+		}
+
+		if now.Before(departure.Add(time.Duration(minDelayHours) * time.Hour)) {
+			return errors.New("flight is too recent to claim")
+		}
+
+		if !lateClaimAccepted && today.After(departure.AddDate(0, 0, maxAgeDays)) {
+			return errors.New("claim window has expired")
+		}
+	}
+
+	return nil
+}
+
+func (s service) claimMaxAgeDays() int {
+	if s.cfg.ClaimMaxAgeDays <= 0 {
+		return defaultClaimMaxAgeDays
+	}
+	return s.cfg.ClaimMaxAgeDays
+}
@@ -0,0 +1,105 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/google/uuid"
+	"github.com/viebiz/lit/iam"
+)
+
+func (s service) GetMyComments(ctx context.Context, reqID string) ([]entity.AccrualRequestComment, error) {
+	existedRequest, err := s.getOwnRequest(ctx, reqID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Mileage().GetAccrualRequestComments(ctx, existedRequest.ID.String(), false)
+}
+
+func (s service) AddMyComment(ctx context.Context, input dto.CommentInput) (entity.AccrualRequestComment, error) {
+	existedRequest, err := s.getOwnRequest(ctx, input.ID)
+	if err != nil {
+		return entity.AccrualRequestComment{}, err
+	}
+
+	comment := newComment(ctx, existedRequest, input, constants.UserRoleMember, false)
+
+	// The reviewer holding the request hears about it first, otherwise whoever picks it up next
+	return s.repo.Mileage().SaveAccrualRequestComment(ctx, comment, commentEvent(comment, constants.NotificationAudienceReviewers, existedRequest.AssignedTo))
+}
+
+func (s service) GetComments(ctx context.Context, reqID string) ([]entity.AccrualRequestComment, error) {
+	existedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, reqID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existedRequest.ID == uuid.Nil {
+		return nil, errors.New("accrual request does not exists")
+	}
+
+	return s.repo.Mileage().GetAccrualRequestComments(ctx, reqID, true)
+}
+
+func (s service) AddComment(ctx context.Context, input dto.AdminCommentInput) (entity.AccrualRequestComment, error) {
+	existedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, input.ID)
+	if err != nil {
+		return entity.AccrualRequestComment{}, err
+	}
+
+	if existedRequest.ID == uuid.Nil {
+		return entity.AccrualRequestComment{}, errors.New("accrual request does not exists")
+	}
+
+	comment := newComment(ctx, existedRequest, input.CommentInput, constants.UserRoleAdmin, input.Internal)
+	if input.Internal {
+		return s.repo.Mileage().SaveAccrualRequestComment(ctx, comment, commentEvent(comment, constants.NotificationAudienceReviewers, existedRequest.AssignedTo))
+	}
+
+	customer, err := s.repo.Customer().GetByID(ctx, existedRequest.CustomerID.String())
+	if err != nil {
+		return entity.AccrualRequestComment{}, err
+	}
+
+	return s.repo.Mileage().SaveAccrualRequestComment(ctx, comment, commentEvent(comment, constants.NotificationAudienceMember, &customer.Auth0UserID))
+}
+
+func newComment(ctx context.Context, req entity.AccrualRequest, input dto.CommentInput, role string, internal bool) entity.AccrualRequestComment {
+	attachments := input.Attachments
+	if attachments == nil {
+		attachments = []string{}
+	}
+
+	return entity.AccrualRequestComment{
+		AccrualRequestID: req.ID,
+		AuthorID:         iam.GetUserProfileFromContext(ctx).ID(),
+		AuthorRole:       role,
+		Body:             input.Body,
+		Internal:         internal,
+		Attachments:      attachments,
+	}
+}
+
+func commentEvent(comment entity.AccrualRequestComment, audience string, recipientID *string) entity.NotificationEvent {
+	// A comment never notifies its own author
+	if recipientID != nil && *recipientID == comment.AuthorID {
+		recipientID = nil
+	}
+
+	return entity.NotificationEvent{
+		Kind:             constants.NotificationKindCommentAdded,
+		Audience:         audience,
+		RecipientID:      recipientID,
+		AccrualRequestID: &comment.AccrualRequestID,
+		Payload: map[string]any{
+			"author_id":   comment.AuthorID,
+			"author_role": comment.AuthorRole,
+			"internal":    comment.Internal,
+			"attachments": len(comment.Attachments),
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/google/uuid"
+	"github.com/viebiz/lit/iam"
+)
+
+func (s service) GetMyAccrualRequest(ctx context.Context, reqID string) (dto.AccrualRequestDetail, error) {
+	customer, err := s.repo.Customer().GetByUserID(ctx, iam.GetUserProfileFromContext(ctx).ID())
+	if err != nil {
+		return dto.AccrualRequestDetail{}, err
+	}
+
+	if customer.ID == uuid.Nil {
+		return dto.AccrualRequestDetail{}, errors.New("user not found")
+	}
+
+	existedRequest, err := s.repo.Mileage().GetAccrualRequestDetail(ctx, reqID)
+	if err != nil {
+		return dto.AccrualRequestDetail{}, err
+	}
+
+	// Requests of other customers are reported as missing rather than forbidden
+	if existedRequest.ID == uuid.Nil || existedRequest.CustomerID != customer.ID {
+		return dto.AccrualRequestDetail{}, errors.New("accrual request does not exists")
+	}
+
+	// Fraud signals are for reviewers only
+	existedRequest.RiskScore = 0
+	existedRequest.RiskHits = nil
+
+	return s.toDetail(ctx, existedRequest, false)
+}
+
+func (s service) GetAccrualRequest(ctx context.Context, reqID string) (dto.AccrualRequestDetail, error) {
+	existedRequest, err := s.repo.Mileage().GetAccrualRequestDetail(ctx, reqID)
+	if err != nil {
+		return dto.AccrualRequestDetail{}, err
+	}
+
+	if existedRequest.ID == uuid.Nil {
+		return dto.AccrualRequestDetail{}, errors.New("accrual request does not exists")
+	}
+
+	return s.toDetail(ctx, existedRequest, true)
+}
+
+func (s service) toDetail(ctx context.Context, req entity.AccrualRequest, admin bool) (dto.AccrualRequestDetail, error) {
+	ledgers, err := s.repo.Mileage().GetAccrualRequestLedgers(ctx, req.ID.String())
+	if err != nil {
+		return dto.AccrualRequestDetail{}, err
+	}
+
+	comments, err := s.repo.Mileage().GetAccrualRequestComments(ctx, req.ID.String(), admin)
+	if err != nil {
+		return dto.AccrualRequestDetail{}, err
+	}
+
+	s.localizeRejections(ctx, &req)
+	detail := dto.AccrualRequestDetail{
+		AccrualRequest: req,
+		Comments:       comments,
+		Ledgers:        ledgers,
+		PointsProvider: s.points.Provider(),
+		Deposits:       depositOutcomes(req, ledgers),
+	}
+	if req.Customer != nil {
+		detail.Customer = &dto.CustomerSummary{
+			ID:         req.Customer.ID,
+			Email:      req.Customer.Email,
+			FirstName:  req.Customer.FirstName,
+			LastName:   req.Customer.LastName,
+			MemberTier: req.Customer.MemberTier,
+		}
+	}
+
+	// The same admin often appears more than once, look each one up only once
+	identities := make(map[string]*dto.ReviewerIdentity)
+	identify := func(userID *string) (*dto.ReviewerIdentity, error) {
+		if userID == nil {
+			return nil, nil
+		}
+
+		if identity, ok := identities[*userID]; ok {
+			return identity, nil
+		}
+
+		identity, err := s.reviewerIdentity(ctx, *userID, admin)
+		if err != nil {
+			return nil, err
+		}
+		identities[*userID] = identity
+		return identity, nil
+	}
+
+	if detail.Reviewer, err = identify(req.ReviewerID); err != nil {
+		return dto.AccrualRequestDetail{}, err
+	}
+	if detail.FirstApprover, err = identify(req.FirstApproverID); err != nil {
+		return dto.AccrualRequestDetail{}, err
+	}
+	if detail.SecondApprover, err = identify(req.SecondApproverID); err != nil {
+		return dto.AccrualRequestDetail{}, err
+	}
+
+	return detail, nil
+}
+
+// reviewerIdentity names the admin behind a user ID, admins signed in to the member site have a customer record
+func (s service) reviewerIdentity(ctx context.Context, userID string, admin bool) (*dto.ReviewerIdentity, error) {
+	if userID == constants.SystemReviewerID {
+		return &dto.ReviewerIdentity{ID: userID, Name: "Auto approval", System: true}, nil
+	}
+
+	identity := &dto.ReviewerIdentity{ID: userID}
+	customer, err := s.repo.Customer().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer.ID != uuid.Nil {
+		identity.Name = strings.TrimSpace(customer.FirstName + " " + customer.LastName)
+		if admin {
+			identity.Email = customer.Email
+		}
+	}
+
+	return identity, nil
+}
+
+// depositOutcomes reports per segment what the points account did with its accrual ledger row, as recorded when it was posted
+func depositOutcomes(req entity.AccrualRequest, ledgers []entity.MilesLedger) []dto.SegmentDeposit {
+	credited := make(map[uuid.UUID]entity.MilesLedger, len(ledgers))
+	for _, ledger := range ledgers {
+		if ledger.Kind == constants.LedgerKindAccrual && ledger.SegmentID != nil {
+			credited[*ledger.SegmentID] = ledger
+		}
+	}
+
+	deposits := make([]dto.SegmentDeposit, 0, len(req.Segments))
+	for _, segment := range req.Segments {
+		deposit := dto.SegmentDeposit{SegmentID: segment.ID}
+		ledger, ok := credited[segment.ID]
+		switch {
+		case segment.Status == constants.SegmentStatusPending:
+			deposit.Status = "pending"
+		case segment.Status != constants.SegmentStatusApproved:
+			deposit.Status = "not_applicable"
+		case !ok:
+			// Approved before ledger rows were written ahead of the deposit, nothing was recorded about it
+			deposit.Status = "unknown"
+		case ledger.PostedAt == nil && ledger.PostingError != nil:
+			deposit.Status = "failed"
+		case ledger.PostedAt == nil:
+			deposit.Status = "posting"
+		case req.Status == constants.RequestStatusReversed:
+			deposit.Status = "reversed"
+		default:
+			deposit.Status = "deposited"
+		}
+
+		if ok {
+			deposit.QualifyingMiles = ledger.QualifyingMilesDelta
+			deposit.BonusMiles = ledger.BonusMilesDelta
+			deposit.ReferenceID = ledger.ReferenceID
+			deposit.PostedAt = ledger.PostedAt
+			deposit.Error = ledger.PostingError
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	return deposits
+}
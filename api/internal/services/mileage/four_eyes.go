@@ -0,0 +1,54 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/viebiz/lit/iam"
+)
+
+const defaultSecondApprovalMiles miles.Miles = 20000
+
+// needsSecondApproval tells whether crediting the request takes a second admin, either for its value or because an admin overrode the claim rules
+func (s service) needsSecondApproval(req entity.AccrualRequest) bool {
+	threshold := s.cfg.SecondApprovalMiles
+	if threshold <= 0 {
+		threshold = defaultSecondApprovalMiles
+	}
+
+	return req.QualifyingMiles+req.BonusMiles+req.TierBonusMiles >= threshold || req.LateClaimApprovedBy != nil
+}
+
+// firstApproval records the first admin's approval, nothing is credited until a second admin approves
+func (s service) firstApproval(ctx context.Context, req entity.AccrualRequest) error {
+	userID := iam.GetUserProfileFromContext(ctx).ID()
+	now := time.Now().UTC()
+	req.FirstApproverID = &userID
+	req.FirstApprovedAt = &now
+	req.SecondApproverID = nil
+	req.SecondApprovedAt = nil
+
+	loadedStatus := req.Status
+	if err := transition(ctx, &req, constants.RequestStatusPendingSecondApproval, nil); err != nil {
+		return err
+	}
+
+	return s.updateRequest(ctx, req, loadedStatus, userID)
+}
+
+func secondApproval(ctx context.Context, req *entity.AccrualRequest) error {
+	userID := iam.GetUserProfileFromContext(ctx).ID()
+	if req.FirstApproverID == nil || *req.FirstApproverID == userID {
+		return errors.New("second approval must come from a different admin")
+	}
+
+	now := time.Now().UTC()
+	req.SecondApproverID = &userID
+	req.SecondApprovedAt = &now
+
+	return nil
+}
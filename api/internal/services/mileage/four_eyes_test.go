@@ -0,0 +1,50 @@
+package mileage
+
+import (
+	"testing"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/config"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+)
+
+func TestNeedsSecondApproval(t *testing.T) {
+	admin := "admin"
+
+	tcs := map[string]struct {
+		givenThreshold miles.Miles
+		givenRequest   entity.AccrualRequest
+		expResult      bool
+	}{
+		"below the default threshold": {
+			givenRequest: entity.AccrualRequest{QualifyingMiles: 10000, BonusMiles: 9000, TierBonusMiles: 999},
+		},
+		"at the default threshold": {
+			givenRequest: entity.AccrualRequest{QualifyingMiles: 10000, BonusMiles: 9000, TierBonusMiles: 1000},
+			expResult:    true,
+		},
+		"configured threshold": {
+			givenThreshold: 5000,
+			givenRequest:   entity.AccrualRequest{QualifyingMiles: 3000, BonusMiles: 2000},
+			expResult:      true,
+		},
+		"below the configured threshold": {
+			givenThreshold: 5000,
+			givenRequest:   entity.AccrualRequest{QualifyingMiles: 3000, BonusMiles: 1999},
+		},
+		"late claim override": {
+			givenRequest: entity.AccrualRequest{QualifyingMiles: 100, LateClaimApprovedBy: &admin},
+			expResult:    true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			svc := service{cfg: config.AccrualConfig{SecondApprovalMiles: tc.givenThreshold}}
+
+			if got := svc.needsSecondApproval(tc.givenRequest); got != tc.expResult {
+				t.Fatalf("expected %t, got %t", tc.expResult, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/google/uuid"
+	"github.com/viebiz/lit/iam"
+)
+
+func (s service) RequestAccrualInfo(ctx context.Context, reqID string, message string) error {
+	existedRequest, err := s.getReviewableRequest(ctx, reqID)
+	if err != nil {
+		return err
+	}
+
+	loadedStatus := existedRequest.Status
+	if err := transition(ctx, &existedRequest, constants.RequestStatusNeedsInfo, &message); err != nil {
+		return err
+	}
+
+	userID := iam.GetUserProfileFromContext(ctx).ID()
+	now := time.Now().UTC()
+	existedRequest.InfoRequest = &message
+	existedRequest.ReviewerID = &userID
+	existedRequest.ReviewedAt = &now
+
+	return s.updateRequest(ctx, existedRequest, loadedStatus, userID)
+}
+
+func (s service) ResubmitAccrualRequest(ctx context.Context, input dto.ResubmitAccrualRequestInput) error {
+	existedRequest, err := s.getOwnRequest(ctx, input.ID)
+	if err != nil {
+		return err
+	}
+
+	if existedRequest.Status != constants.RequestStatusNeedsInfo || existedRequest.InfoRequest == nil {
+		return errors.New("invalid status")
+	}
+
+	// 1. Apply the member's changes, keeping what each field was before
+	changes := make(map[string]entity.FieldChange)
+	track(changes, "pnr", &existedRequest.PNR, input.PNR)
+	track(changes, "ticket_image_url", &existedRequest.TicketImageURL, input.TicketImageURL)
+	track(changes, "boarding_pass_image_url", &existedRequest.BoardingPassImageURL, input.BoardingPassImageURL)
+	if _, ok := changes["boarding_pass_image_url"]; ok {
+		// A new image has not been checked by anyone
+		existedRequest.BoardingPassVerifiedBy = nil
+		existedRequest.BoardingPassVerifiedAt = nil
+	}
+
+	before := len(changes)
+	track(changes, "base_fare", &existedRequest.BaseFare, input.BaseFare)
+	track(changes, "surcharges", &existedRequest.Surcharges, input.Surcharges)
+	trackOptional(changes, "fare_currency", &existedRequest.FareCurrency, upper(input.FareCurrency))
+	fareChanged := len(changes) > before
+
+	segmentsChanged := false
+	for _, segmentInput := range input.Segments {
+		idx, err := findPendingSegment(existedRequest, segmentInput.ID)
+		if err != nil {
+			return err
+		}
+
+		segment := &existedRequest.Segments[idx]
+		before = len(changes)
+		prefix := fmt.Sprintf("segments[%d].", segment.SegmentNo)
+		track(changes, prefix+"coupon_number", &segment.CouponNumber, segmentInput.CouponNumber)
+		track(changes, prefix+"carrier", &segment.Carrier, upper(segmentInput.Carrier))
+		trackOptional(changes, prefix+"operating_carrier", &segment.OperatingCarrier, upper(segmentInput.OperatingCarrier))
+		track(changes, prefix+"booking_class", &segment.BookingClass, upper(segmentInput.BookingClass))
+		track(changes, prefix+"from_code", &segment.FromCode, upper(segmentInput.FromCode))
+		track(changes, prefix+"to_code", &segment.ToCode, upper(segmentInput.ToCode))
+		track(changes, prefix+"departure_date", &segment.DepartureDate, segmentInput.DepartureDate)
+		segmentsChanged = segmentsChanged || len(changes) > before
+	}
+
+	if len(changes) == 0 && (input.Note == nil || strings.TrimSpace(*input.Note) == "") {
+		return errors.New("resubmission has no changes")
+	}
+
+	// 2. Changed flights go through the same checks as a new claim
+	if segmentsChanged {
+		if err := s.checkClaimWindow(existedRequest.Segments, time.Now().UTC(), existedRequest.LateClaimApprovedBy != nil); err != nil {
+			return err
+		}
+
+		for _, segment := range existedRequest.Segments {
+			claimed, err := s.repo.Mileage().GetClaimedSegment(ctx, existedRequest.CustomerID.String(), segment)
+			if err != nil {
+				return err
+			}
+
+			if claimed.ID != uuid.Nil && claimed.AccrualRequestID != existedRequest.ID {
+				return errors.New("accrual request already exists")
+			}
+		}
+	}
+
+	if segmentsChanged {
+		if err := s.risk.Assess(ctx, &existedRequest); err != nil {
+			return err
+		}
+	}
+
+	if segmentsChanged || fareChanged {
+		if err := s.repriceOpenSegments(ctx, &existedRequest); err != nil {
+			return err
+		}
+	}
+
+	// 3. Keep the round trip and queue the request for review again
+	revision := entity.AccrualRequestRevision{
+		RevisionNo:  len(existedRequest.Revisions) + 1,
+		InfoRequest: *existedRequest.InfoRequest,
+		MemberNote:  input.Note,
+		Changes:     changes,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if existedRequest.ReviewerID != nil && existedRequest.ReviewedAt != nil {
+		revision.RequestedBy = *existedRequest.ReviewerID
+		revision.RequestedAt = *existedRequest.ReviewedAt
+	}
+	existedRequest.Revisions = append(existedRequest.Revisions, revision)
+	existedRequest.InfoRequest = nil
+
+	// The re-priced request goes back to the open queue, a lock left over from before the info request is released
+	// rather than kept for a reviewer who never saw these changes. Nobody can lock it while it waits on the member
+	existedRequest.LockedUntil = nil
+
+	if err := transition(ctx, &existedRequest, constants.RequestStatusSubmitted, input.Note); err != nil {
+		return err
+	}
+
+	return s.updateRequest(ctx, existedRequest, constants.RequestStatusNeedsInfo, "")
+}
+
+// repriceOpenSegments prices the request again but keeps what was already decided for the other segments
+func (s service) repriceOpenSegments(ctx context.Context, req *entity.AccrualRequest) error {
+	decided := make(map[int]entity.AccrualRequestSegment)
+	for idx, segment := range req.Segments {
+		if segment.Status != constants.SegmentStatusPending {
+			decided[idx] = segment
+		}
+	}
+
+	if err := s.calculateMiles(ctx, req); err != nil {
+		return err
+	}
+
+	for idx, segment := range decided {
+		req.Segments[idx] = segment
+	}
+	summarise(req)
+
+	return nil
+}
+
+func track[T comparable](changes map[string]entity.FieldChange, field string, current *T, value *T) {
+	if value == nil || *value == *current {
+		return
+	}
+
+	changes[field] = entity.FieldChange{Old: *current, New: *value}
+	*current = *value
+}
+
+func trackOptional[T comparable](changes map[string]entity.FieldChange, field string, current **T, value *T) {
+	if value == nil || (*current != nil && **current == *value) {
+		return
+	}
+
+	var old any
+	if *current != nil {
+		old = **current
+	}
+	changes[field] = entity.FieldChange{Old: old, New: *value}
+	*current = value
+}
+
+func upper(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	upper := strings.ToUpper(*value)
+	return &upper
+}
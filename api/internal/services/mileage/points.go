@@ -0,0 +1,121 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+	"github.com/google/uuid"
+)
+
+// errPointsUpstream marks a failure of the external points system, the request itself may be fine
+var errPointsUpstream = errors.New("points provider failed")
+
+const (
+	pointsProviderLocal    = "local"
+	pointsProviderSessionM = "sessionm"
+)
+
+// pointsAccount is the balance approved miles are credited to
+type pointsAccount interface {
+	// Provider names the system keeping the balance
+	Provider() string
+
+	// MemberTier returns the customer's current tier, used to price tier bonus uplifts
+	MemberTier(ctx context.Context, customer entity.Customer) (string, error)
+
+	// Post moves the balance by the signed deltas of a pending ledger row, keyed by its reference so posting it again cannot move the miles twice
+	Post(ctx context.Context, ledger entity.MilesLedger) error
+
+	// Balance returns the qualifying and bonus miles the customer still has available to spend
+	Balance(ctx context.Context, customer entity.Customer) (qualifyingMiles miles.Miles, bonusMiles miles.Miles, err error)
+}
+
+// localPoints keeps the balance on the customer record
+type localPoints struct {
+	repo repository.Repository
+}
+
+func (p localPoints) Provider() string {
+	return pointsProviderLocal
+}
+
+func (p localPoints) MemberTier(_ context.Context, customer entity.Customer) (string, error) {
+	return customer.MemberTier, nil
+}
+
+// Post moves the customer totals in the same transaction that marks the row posted
+func (p localPoints) Post(ctx context.Context, ledger entity.MilesLedger) error {
+	return p.repo.Mileage().PostLedgerMiles(ctx, *ledger.ReferenceID, time.Now().UTC())
+}
+
+func (p localPoints) Balance(_ context.Context, customer entity.Customer) (miles.Miles, miles.Miles, error) {
+	return customer.QualifyingMilesTotal, customer.BonusMilesTotal, nil
+}
+
+// splitBySign turns a row that credits one bucket and debits the other into a credit row and a debit row, each with its
+// own reference, so the points account never runs two operations that could half succeed under one reference
+func splitBySign(ledger entity.MilesLedger) []entity.MilesLedger {
+	credit, debit := ledger, ledger
+	credit.QualifyingMilesDelta, debit.QualifyingMilesDelta = max(ledger.QualifyingMilesDelta, 0), min(ledger.QualifyingMilesDelta, 0)
+	credit.BonusMilesDelta, debit.BonusMilesDelta = max(ledger.BonusMilesDelta, 0), min(ledger.BonusMilesDelta, 0)
+
+	if credit.QualifyingMilesDelta == 0 && credit.BonusMilesDelta == 0 {
+		return []entity.MilesLedger{ledger}
+	}
+	if debit.QualifyingMilesDelta == 0 && debit.BonusMilesDelta == 0 {
+		return []entity.MilesLedger{ledger}
+	}
+
+	creditReferenceID := *ledger.ReferenceID + ":credit"
+	debitReferenceID := *ledger.ReferenceID + ":debit"
+	credit.ReferenceID = &creditReferenceID
+	debit.ReferenceID = &debitReferenceID
+	debit.ID = uuid.Nil
+	return []entity.MilesLedger{credit, debit}
+}
+
+// RetryDeposits posts the rows a failed approval, reversal or re-price left pending, rows already posted are skipped
+func (s service) RetryDeposits(ctx context.Context, reqID string) error {
+	existedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, reqID)
+	if err != nil {
+		return err
+	}
+
+	if existedRequest.ID == uuid.Nil {
+		return errors.New("accrual request does not exists")
+	}
+
+	pending, err := s.repo.Mileage().GetPendingLedgers(ctx, reqID)
+	if err != nil {
+		return err
+	}
+
+	return s.postLedgers(ctx, pending)
+}
+
+// postLedgers moves the points balance for the ledger rows still pending and records the outcome on each row.
+// A row that failed keeps the reason, retrying the deposits, reversing or re-pricing the request posts it again
+func (s service) postLedgers(ctx context.Context, ledgers []entity.MilesLedger) error {
+	for _, ledger := range ledgers {
+		if ledger.PostedAt != nil || ledger.ReferenceID == nil {
+			continue
+		}
+
+		if err := s.points.Post(ctx, ledger); err != nil {
+			if failErr := s.repo.Mileage().FailLedgerPosting(ctx, *ledger.ReferenceID, err.Error()); failErr != nil {
+				return errors.Join(err, failErr)
+			}
+			return err
+		}
+
+		if err := s.repo.Mileage().MarkLedgerPosted(ctx, *ledger.ReferenceID, time.Now().UTC()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
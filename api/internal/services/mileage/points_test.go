@@ -0,0 +1,184 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+	mileagerepo "github.com/erwin-lovecraft/aegismiles/internal/repository/mileage"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/campaign"
+	"github.com/google/uuid"
+	"github.com/viebiz/lit/iam"
+)
+
+// fakeRepo serves the mileage repository only, calling anything else panics
+type fakeRepo struct {
+	repository.Repository
+	mileage *fakeMileageRepo
+}
+
+func (r fakeRepo) Mileage() mileagerepo.Repository {
+	return r.mileage
+}
+
+// fakeMileageRepo keeps the ledger outcomes the service records, calling anything else panics
+type fakeMileageRepo struct {
+	mileagerepo.Repository
+	saved  []entity.MilesLedger
+	posted []string
+	failed map[string]string
+}
+
+func (r *fakeMileageRepo) UpdateAccrualRequest(_ context.Context, _ entity.AccrualRequest, _ string, _ string, _ time.Time, ledgers ...entity.MilesLedger) (bool, error) {
+	r.saved = append(r.saved, ledgers...)
+	return true, nil
+}
+
+func (r *fakeMileageRepo) MarkLedgerPosted(_ context.Context, referenceID string, _ time.Time) error {
+	r.posted = append(r.posted, referenceID)
+	return nil
+}
+
+func (r *fakeMileageRepo) FailLedgerPosting(_ context.Context, referenceID string, reason string) error {
+	if r.failed == nil {
+		r.failed = make(map[string]string)
+	}
+	r.failed[referenceID] = reason
+	return nil
+}
+
+// fakePoints refuses the rows listed in refuse
+type fakePoints struct {
+	refuse map[string]bool
+}
+
+func (p fakePoints) Provider() string {
+	return "fake"
+}
+
+func (p fakePoints) MemberTier(_ context.Context, customer entity.Customer) (string, error) {
+	return customer.MemberTier, nil
+}
+
+func (p fakePoints) Post(_ context.Context, ledger entity.MilesLedger) error {
+	if p.refuse[*ledger.ReferenceID] {
+		return errPointsUpstream
+	}
+	return nil
+}
+
+func (p fakePoints) Balance(_ context.Context, _ entity.Customer) (miles.Miles, miles.Miles, error) {
+	return 0, 0, nil
+}
+
+// noCampaigns runs no promotions
+type noCampaigns struct {
+	campaign.Service
+}
+
+func (noCampaigns) Evaluate(_ context.Context, _ entity.AccrualRequest, _ entity.AccrualRequestSegment) ([]dto.CampaignAward, error) {
+	return nil, nil
+}
+
+func pendingLedger(referenceID string) entity.MilesLedger {
+	return entity.MilesLedger{QualifyingMilesDelta: 100, ReferenceID: &referenceID}
+}
+
+func TestPostLedgers(t *testing.T) {
+	postedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	alreadyPosted := pendingLedger("posted")
+	alreadyPosted.PostedAt = &postedAt
+
+	tcs := map[string]struct {
+		givenLedgers []entity.MilesLedger
+		givenRefuse  map[string]bool
+		expErr       error
+		expPosted    []string
+		expFailed    []string
+	}{
+		"all rows posted": {
+			givenLedgers: []entity.MilesLedger{pendingLedger("a"), pendingLedger("b")},
+			expPosted:    []string{"a", "b"},
+		},
+		"posted rows are skipped": {
+			givenLedgers: []entity.MilesLedger{alreadyPosted, pendingLedger("a")},
+			expPosted:    []string{"a"},
+		},
+		"refused row keeps the reason and stops the run": {
+			givenLedgers: []entity.MilesLedger{pendingLedger("a"), pendingLedger("b"), pendingLedger("c")},
+			givenRefuse:  map[string]bool{"b": true},
+			expErr:       errPointsUpstream,
+			expPosted:    []string{"a"},
+			expFailed:    []string{"b"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			mileageRepo := &fakeMileageRepo{}
+			svc := service{repo: fakeRepo{mileage: mileageRepo}, points: fakePoints{refuse: tc.givenRefuse}}
+
+			err := svc.postLedgers(context.Background(), tc.givenLedgers)
+
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("expected error %v, got %v", tc.expErr, err)
+			}
+			if len(mileageRepo.posted) != len(tc.expPosted) {
+				t.Fatalf("expected posted %v, got %v", tc.expPosted, mileageRepo.posted)
+			}
+			for idx, referenceID := range tc.expPosted {
+				if mileageRepo.posted[idx] != referenceID {
+					t.Fatalf("expected posted %v, got %v", tc.expPosted, mileageRepo.posted)
+				}
+			}
+			if len(mileageRepo.failed) != len(tc.expFailed) {
+				t.Fatalf("expected failed %v, got %v", tc.expFailed, mileageRepo.failed)
+			}
+			for _, referenceID := range tc.expFailed {
+				if mileageRepo.failed[referenceID] != errPointsUpstream.Error() {
+					t.Fatalf("expected %s to keep the reason, got %v", referenceID, mileageRepo.failed)
+				}
+			}
+		})
+	}
+}
+
+func TestApproveSegmentsKeepsApprovalWhenPostingFails(t *testing.T) {
+	segmentID := uuid.New()
+	req := entity.AccrualRequest{
+		ID:     uuid.New(),
+		Status: constants.RequestStatusSubmitted,
+		Segments: []entity.AccrualRequestSegment{{
+			ID:              segmentID,
+			Status:          constants.SegmentStatusPending,
+			DepartureDate:   time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+			QualifyingMiles: 500,
+		}},
+	}
+	mileageRepo := &fakeMileageRepo{}
+	svc := service{
+		repo:     fakeRepo{mileage: mileageRepo},
+		points:   fakePoints{refuse: map[string]bool{segmentID.String(): true}},
+		campaign: noCampaigns{},
+	}
+	ctx := iam.SetUserProfileInContext(context.Background(), iam.NewUserProfile("reviewer", []string{constants.UserRoleAdmin}, nil))
+
+	if err := svc.approveSegments(ctx, req, []int{0}); err != nil {
+		t.Fatalf("expected the approval to succeed, got %v", err)
+	}
+	if len(mileageRepo.saved) != 1 {
+		t.Fatalf("expected the accrual ledger to be saved, got %v", mileageRepo.saved)
+	}
+	if _, ok := mileageRepo.failed[segmentID.String()]; !ok {
+		t.Fatalf("expected the refused deposit to be recorded as failed, got %v", mileageRepo.failed)
+	}
+	if len(mileageRepo.posted) != 0 {
+		t.Fatalf("expected nothing posted, got %v", mileageRepo.posted)
+	}
+}
@@ -0,0 +1,230 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/geo"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+)
+
+// calculateMiles prices every segment of the request and refreshes the request totals
+func (s service) calculateMiles(ctx context.Context, req *entity.AccrualRequest) error {
+	// Distances are resolved up front since revenue earning prorates the fare over the whole itinerary
+	var totalDistance int
+	for idx := range req.Segments {
+		if err := s.checkBookingClass(ctx, req.Segments[idx].BookingClass); err != nil {
+			return err
+		}
+
+		if err := s.resolveDistance(ctx, &req.Segments[idx]); err != nil {
+			return err
+		}
+		totalDistance += req.Segments[idx].DistanceMiles
+	}
+
+	for idx := range req.Segments {
+		if err := s.priceSegment(ctx, *req, &req.Segments[idx], totalDistance); err != nil {
+			return err
+		}
+	}
+
+	summarise(req)
+	return nil
+}
+
+func (s service) checkBookingClass(ctx context.Context, code string) error {
+	bookingClass, err := s.repo.BookingClass().GetBookingClass(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if bookingClass.Code == "" {
+		return errors.New("booking class is unknown")
+	}
+
+	if !bookingClass.Eligible {
+		return errors.New("booking class is not eligible for accrual")
+	}
+
+	return nil
+}
+
+func (s service) priceSegment(ctx context.Context, req entity.AccrualRequest, segment *entity.AccrualRequestSegment, totalDistance int) error {
+	// Price with the carrier's chart that was in force on the departure date
+	operatingCarrier := segment.Carrier
+	if segment.OperatingCarrier != nil {
+		operatingCarrier = *segment.OperatingCarrier
+	}
+
+	chart, err := s.repo.AccrualRate().GetChartAt(ctx, segment.Carrier, operatingCarrier, segment.DepartureDate)
+	if err != nil {
+		return err
+	}
+
+	if chart.ID == uuid.Nil {
+		return errors.New("carrier is not eligible for accrual")
+	}
+
+	segment.AccrualRateChartID = &chart.ID
+
+	if chart.EarningMode == constants.EarningModeRevenue {
+		return s.priceSegmentByRevenue(ctx, req, segment, chart, totalDistance)
+	}
+
+	accrualRate, ok := findAccrualRate(chart, segment.BookingClass)
+	if !ok {
+		return errors.New("booking class is not eligible for accrual")
+	}
+	segment.EarningMode = constants.EarningModeDistance
+	segment.EligibleSpend = 0
+	segment.ExchangeRate = 0
+
+	segment.QualifyingAccrualRate = accrualRate.QualifyingRate
+	segment.QualifyingMiles = s.cfg.Rounding.Apply(segment.QualifyingAccrualRate, miles.Miles(segment.DistanceMiles))
+
+	segment.BonusAccrualRate = accrualRate.BonusTiers.At(float64(segment.DistanceMiles))
+	segment.BonusMiles = s.cfg.Rounding.Apply(segment.BonusAccrualRate, miles.Miles(segment.DistanceMiles))
+
+	// Tier uplift is a share of the bonus miles, kept apart so reviewers can see it. It is taken from the bonus before
+	// rounding so the member is not rounded twice
+	segment.TierBonusRate = chart.TierBonusUplifts[req.MemberTier]
+	segment.TierBonusMiles = s.cfg.Rounding.Round(segment.TierBonusRate * segment.BonusAccrualRate * float64(segment.DistanceMiles))
+
+	return nil
+}
+
+// priceSegmentByRevenue earns on the segment's share of the base fare and carrier surcharges, converted into the program currency
+func (s service) priceSegmentByRevenue(ctx context.Context, req entity.AccrualRequest, segment *entity.AccrualRequestSegment, chart entity.AccrualRateChart, totalDistance int) error {
+	if req.FareCurrency == nil || req.BaseFare <= 0 || totalDistance <= 0 {
+		return errors.New("fare is required for revenue earning")
+	}
+
+	programCurrency := *req.FareCurrency
+	if chart.ProgramCurrency != nil {
+		programCurrency = *chart.ProgramCurrency
+	}
+
+	exchangeRate := 1.0
+	if programCurrency != *req.FareCurrency {
+		rate, err := s.repo.ExchangeRate().GetRateAt(ctx, *req.FareCurrency, programCurrency, segment.DepartureDate)
+		if err != nil {
+			return err
+		}
+
+		if rate.ID == 0 {
+			return errors.New("exchange rate is not available")
+		}
+		exchangeRate = rate.Rate
+	}
+
+	// Members without a published multiplier earn at the entry tier's
+	multiplier, ok := chart.RevenueRates[req.MemberTier]
+	if !ok {
+		multiplier = chart.RevenueRates[constants.MemberTierRegister]
+	}
+
+	share := float64(segment.DistanceMiles) / float64(totalDistance)
+
+	segment.EarningMode = constants.EarningModeRevenue
+	segment.ExchangeRate = exchangeRate
+	segment.EligibleSpend = (req.BaseFare + req.Surcharges) * share * exchangeRate
+	segment.QualifyingAccrualRate = multiplier
+	segment.QualifyingMiles = s.cfg.Rounding.Round(segment.EligibleSpend * multiplier)
+	segment.BonusAccrualRate = 0
+	segment.BonusMiles = 0
+	segment.TierBonusRate = 0
+	segment.TierBonusMiles = 0
+
+	return nil
+}
+
+// resolveDistance prefers the published distance and falls back to the great-circle distance between the airports
+func (s service) resolveDistance(ctx context.Context, segment *entity.AccrualRequestSegment) error {
+	from, err := s.repo.Airport().GetAirport(ctx, segment.FromCode)
+	if err != nil {
+		return err
+	}
+
+	to, err := s.repo.Airport().GetAirport(ctx, segment.ToCode)
+	if err != nil {
+		return err
+	}
+
+	if from.Code == "" || to.Code == "" {
+		return errors.New("airport code is not recognised")
+	}
+
+	if from.Code == to.Code {
+		return errors.New("route distance is not available")
+	}
+
+	distance, err := s.repo.Mileage().GetTravelDistance(ctx, segment.FromCode, segment.ToCode)
+	if err != nil {
+		return err
+	}
+
+	if distance.ID != 0 {
+		segment.DistanceMiles = distance.Miles
+		segment.DistanceSource = constants.DistanceSourcePublished
+		return nil
+	}
+
+	segment.DistanceMiles = int(math.Round(geo.GreatCircleMiles(from.Latitude, from.Longitude, to.Latitude, to.Longitude)))
+	segment.DistanceSource = constants.DistanceSourceComputed
+	return nil
+}
+
+func findAccrualRate(chart entity.AccrualRateChart, bookingClass string) (entity.AccrualRate, bool) {
+	for _, rate := range chart.Rates {
+		if rate.BookingClass == bookingClass {
+			return rate, true
+		}
+	}
+	return entity.AccrualRate{}, false
+}
+
+// summarise copies the itinerary overview onto the request and totals the segments that were not rejected
+func summarise(req *entity.AccrualRequest) {
+	if len(req.Segments) == 0 {
+		return
+	}
+
+	first, last := req.Segments[0], req.Segments[len(req.Segments)-1]
+	req.Carrier = first.Carrier
+	req.OperatingCarrier = first.OperatingCarrier
+	req.BookingClass = first.BookingClass
+	req.FromCode = first.FromCode
+	req.ToCode = last.ToCode
+	req.DepartureDate = first.DepartureDate
+	req.EarningMode = first.EarningMode
+	req.AccrualRateChartID = first.AccrualRateChartID
+	req.QualifyingAccrualRate = first.QualifyingAccrualRate
+	req.BonusAccrualRate = first.BonusAccrualRate
+	req.TierBonusRate = first.TierBonusRate
+
+	req.DistanceMiles = 0
+	req.DistanceSource = constants.DistanceSourcePublished
+	req.QualifyingMiles = 0
+	req.BonusMiles = 0
+	req.TierBonusMiles = 0
+	for _, segment := range req.Segments {
+		// A single computed distance is enough for reviewers to double check the claim
+		if segment.DistanceSource == constants.DistanceSourceComputed {
+			req.DistanceSource = constants.DistanceSourceComputed
+		}
+
+		if segment.Status == constants.SegmentStatusRejected {
+			continue
+		}
+
+		req.DistanceMiles += segment.DistanceMiles
+		req.QualifyingMiles += segment.QualifyingMiles
+		req.BonusMiles += segment.BonusMiles
+		req.TierBonusMiles += segment.TierBonusMiles
+	}
+}
@@ -0,0 +1,141 @@
+package mileage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+)
+
+func TestSummarise(t *testing.T) {
+	departure := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	outbound := entity.AccrualRequestSegment{
+		Carrier:               "VN",
+		BookingClass:          "Y",
+		FromCode:              "SGN",
+		ToCode:                "HAN",
+		DepartureDate:         departure,
+		DistanceMiles:         700,
+		DistanceSource:        constants.DistanceSourcePublished,
+		EarningMode:           constants.EarningModeDistance,
+		QualifyingAccrualRate: 1.1,
+		BonusAccrualRate:      1.4,
+		TierBonusRate:         0.25,
+		QualifyingMiles:       770,
+		BonusMiles:            980,
+		TierBonusMiles:        245,
+		Status:                constants.SegmentStatusApproved,
+	}
+	inbound := entity.AccrualRequestSegment{
+		Carrier:         "VN",
+		BookingClass:    "M",
+		FromCode:        "HAN",
+		ToCode:          "DAD",
+		DepartureDate:   departure.AddDate(0, 0, 3),
+		DistanceMiles:   390,
+		DistanceSource:  constants.DistanceSourcePublished,
+		QualifyingMiles: 429,
+		BonusMiles:      546,
+		TierBonusMiles:  137,
+		Status:          constants.SegmentStatusPending,
+	}
+
+	tcs := map[string]struct {
+		givenSegments      func(outbound, inbound entity.AccrualRequestSegment) []entity.AccrualRequestSegment
+		expToCode          string
+		expDistanceMiles   int
+		expDistanceSource  string
+		expQualifyingMiles miles.Miles
+		expBonusMiles      miles.Miles
+		expTierBonusMiles  miles.Miles
+	}{
+		"single segment": {
+			givenSegments: func(outbound, _ entity.AccrualRequestSegment) []entity.AccrualRequestSegment {
+				return []entity.AccrualRequestSegment{outbound}
+			},
+			expToCode:          "HAN",
+			expDistanceMiles:   700,
+			expDistanceSource:  constants.DistanceSourcePublished,
+			expQualifyingMiles: 770,
+			expBonusMiles:      980,
+			expTierBonusMiles:  245,
+		},
+		"itinerary totals every segment": {
+			givenSegments: func(outbound, inbound entity.AccrualRequestSegment) []entity.AccrualRequestSegment {
+				return []entity.AccrualRequestSegment{outbound, inbound}
+			},
+			expToCode:          "DAD",
+			expDistanceMiles:   1090,
+			expDistanceSource:  constants.DistanceSourcePublished,
+			expQualifyingMiles: 1199,
+			expBonusMiles:      1526,
+			expTierBonusMiles:  382,
+		},
+		"rejected segment earns nothing": {
+			givenSegments: func(outbound, inbound entity.AccrualRequestSegment) []entity.AccrualRequestSegment {
+				inbound.Status = constants.SegmentStatusRejected
+				return []entity.AccrualRequestSegment{outbound, inbound}
+			},
+			expToCode:          "DAD",
+			expDistanceMiles:   700,
+			expDistanceSource:  constants.DistanceSourcePublished,
+			expQualifyingMiles: 770,
+			expBonusMiles:      980,
+			expTierBonusMiles:  245,
+		},
+		"one computed distance marks the request": {
+			givenSegments: func(outbound, inbound entity.AccrualRequestSegment) []entity.AccrualRequestSegment {
+				inbound.DistanceSource = constants.DistanceSourceComputed
+				inbound.Status = constants.SegmentStatusRejected
+				return []entity.AccrualRequestSegment{outbound, inbound}
+			},
+			expToCode:          "DAD",
+			expDistanceMiles:   700,
+			expDistanceSource:  constants.DistanceSourceComputed,
+			expQualifyingMiles: 770,
+			expBonusMiles:      980,
+			expTierBonusMiles:  245,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			req := entity.AccrualRequest{
+				DistanceMiles:   9999,
+				QualifyingMiles: 9999,
+				Segments:        tc.givenSegments(outbound, inbound),
+			}
+
+			summarise(&req)
+
+			if req.Carrier != "VN" || req.BookingClass != "Y" || req.FromCode != "SGN" || !req.DepartureDate.Equal(departure) {
+				t.Fatalf("expected the route to start with the first segment, got %s %s %s %s", req.Carrier, req.BookingClass, req.FromCode, req.DepartureDate)
+			}
+			if req.QualifyingAccrualRate != 1.1 || req.BonusAccrualRate != 1.4 || req.TierBonusRate != 0.25 {
+				t.Fatalf("expected the rates of the first segment, got %v %v %v", req.QualifyingAccrualRate, req.BonusAccrualRate, req.TierBonusRate)
+			}
+			if req.ToCode != tc.expToCode {
+				t.Fatalf("expected to code %s, got %s", tc.expToCode, req.ToCode)
+			}
+			if req.DistanceMiles != tc.expDistanceMiles || req.DistanceSource != tc.expDistanceSource {
+				t.Fatalf("expected distance %d %s, got %d %s", tc.expDistanceMiles, tc.expDistanceSource, req.DistanceMiles, req.DistanceSource)
+			}
+			if req.QualifyingMiles != tc.expQualifyingMiles || req.BonusMiles != tc.expBonusMiles || req.TierBonusMiles != tc.expTierBonusMiles {
+				t.Fatalf("expected miles %d %d %d, got %d %d %d", tc.expQualifyingMiles, tc.expBonusMiles, tc.expTierBonusMiles,
+					req.QualifyingMiles, req.BonusMiles, req.TierBonusMiles)
+			}
+		})
+	}
+}
+
+func TestSummariseWithoutSegments(t *testing.T) {
+	req := entity.AccrualRequest{QualifyingMiles: 100}
+
+	summarise(&req)
+
+	if req.QualifyingMiles != 100 {
+		t.Fatalf("expected a request without segments to be left alone, got %d", req.QualifyingMiles)
+	}
+}
@@ -0,0 +1,109 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/google/uuid"
+	"github.com/viebiz/lit/iam"
+)
+
+func (s service) QuoteAccrual(ctx context.Context, input dto.AccrualQuoteInput) (dto.AccrualQuote, error) {
+	userProfile := iam.GetUserProfileFromContext(ctx)
+
+	customer, err := s.repo.Customer().GetByUserID(ctx, userProfile.ID())
+	if err != nil {
+		return dto.AccrualQuote{}, err
+	}
+
+	if customer.ID == uuid.Nil {
+		return dto.AccrualQuote{}, errors.New("user not found")
+	}
+
+	memberTier, err := s.points.MemberTier(ctx, customer)
+	if err != nil {
+		return dto.AccrualQuote{}, err
+	}
+
+	return s.quote(ctx, entity.AccrualRequest{CustomerID: customer.ID, MemberTier: memberTier}, input)
+}
+
+func (s service) QuotePublicAccrual(ctx context.Context, input dto.AccrualQuoteInput) (dto.AccrualQuote, error) {
+	// Without a customer only promotions open to everyone are applied
+	return s.quote(ctx, entity.AccrualRequest{MemberTier: input.MemberTier}, input)
+}
+
+// quote runs the same pricing as a submitted claim, req carries who the quote is for
+func (s service) quote(ctx context.Context, req entity.AccrualRequest, input dto.AccrualQuoteInput) (dto.AccrualQuote, error) {
+	inputs := input.Segments
+	if len(inputs) == 0 {
+		inputs = []dto.AccrualSegmentInput{{
+			Carrier:          input.Carrier,
+			OperatingCarrier: input.OperatingCarrier,
+			BookingClass:     input.BookingClass,
+			FromCode:         input.FromCode,
+			ToCode:           input.ToCode,
+			DepartureDate:    input.DepartureDate,
+		}}
+	}
+
+	segments, err := toSegmentEntities("", inputs)
+	if err != nil {
+		return dto.AccrualQuote{}, err
+	}
+	req.Status = constants.RequestStatusSubmitted
+	req.BaseFare = input.BaseFare
+	req.Surcharges = input.Surcharges
+	req.FareCurrency = toCurrency(input.FareCurrency)
+	req.Segments = segments
+
+	if err := s.calculateMiles(ctx, &req); err != nil {
+		return dto.AccrualQuote{}, err
+	}
+
+	quote := dto.AccrualQuote{
+		MemberTier:      req.MemberTier,
+		EarningMode:     req.EarningMode,
+		DistanceMiles:   req.DistanceMiles,
+		QualifyingMiles: req.QualifyingMiles,
+		BonusMiles:      req.BonusMiles,
+		TierBonusMiles:  req.TierBonusMiles,
+	}
+
+	for _, segment := range req.Segments {
+		promotions, err := s.campaign.Evaluate(ctx, req, segment)
+		if err != nil {
+			return dto.AccrualQuote{}, err
+		}
+
+		for _, promotion := range promotions {
+			quote.PromotionMiles += promotion.BonusMiles
+		}
+
+		quote.Segments = append(quote.Segments, dto.AccrualQuoteSegment{
+			Carrier:               segment.Carrier,
+			BookingClass:          segment.BookingClass,
+			FromCode:              segment.FromCode,
+			ToCode:                segment.ToCode,
+			DepartureDate:         segment.DepartureDate,
+			DistanceMiles:         segment.DistanceMiles,
+			DistanceSource:        segment.DistanceSource,
+			EarningMode:           segment.EarningMode,
+			EligibleSpend:         segment.EligibleSpend,
+			QualifyingAccrualRate: segment.QualifyingAccrualRate,
+			QualifyingMiles:       segment.QualifyingMiles,
+			BonusAccrualRate:      segment.BonusAccrualRate,
+			BonusMiles:            segment.BonusMiles,
+			TierBonusRate:         segment.TierBonusRate,
+			TierBonusMiles:        segment.TierBonusMiles,
+			Promotions:            promotions,
+		})
+	}
+
+	quote.TotalMiles = quote.QualifyingMiles + quote.BonusMiles + quote.TierBonusMiles + quote.PromotionMiles
+
+	return quote, nil
+}
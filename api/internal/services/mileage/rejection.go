@@ -0,0 +1,135 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit/i18n"
+)
+
+// rejectedReason is a reviewer's rejection ready to be stored on a request or segment
+type rejectedReason struct {
+	Code    string
+	Note    *string
+	Message string // Rendered in the reviewer's language for readers that do not know the codes
+}
+
+func (s service) newRejectedReason(ctx context.Context, rejection dto.Rejection) rejectedReason {
+	code, note := rejection.Reason()
+	reason := rejectedReason{Code: code}
+	if note != "" {
+		reason.Note = &note
+	}
+	reason.Message = s.rejectionMessage(ctx, code, reason.Note)
+	return reason
+}
+
+func (r rejectedReason) applyToRequest(req *entity.AccrualRequest) {
+	req.RejectedReasonCode = &r.Code
+	req.RejectedNote = r.Note
+	req.RejectedReason = &r.Message
+}
+
+func (r rejectedReason) applyToSegment(segment *entity.AccrualRequestSegment) {
+	segment.RejectedReasonCode = &r.Code
+	segment.RejectedNote = r.Note
+	segment.RejectedReason = &r.Message
+}
+
+// localizeRejections renders the rejection reasons in the caller's language, reasons written before the catalogue are left as they are
+func (s service) localizeRejections(ctx context.Context, req *entity.AccrualRequest) {
+	if req.RejectedReasonCode != nil {
+		message := s.rejectionMessage(ctx, *req.RejectedReasonCode, req.RejectedNote)
+		req.RejectedReason = &message
+	}
+
+	for idx := range req.Segments {
+		segment := &req.Segments[idx]
+		if segment.RejectedReasonCode != nil {
+			message := s.rejectionMessage(ctx, *segment.RejectedReasonCode, segment.RejectedNote)
+			segment.RejectedReason = &message
+		}
+	}
+}
+
+func (s service) rejectionMessage(ctx context.Context, code string, note *string) string {
+	// The note is all there is to say for the 'other' code
+	if code == constants.RejectionReasonOther && note != nil {
+		return *note
+	}
+
+	message := s.rejectionLabel(ctx, code)
+	if note != nil {
+		message += " " + *note
+	}
+	return message
+}
+
+// rejectionLabel renders the reason code in the caller's language, falling back to the code itself
+func (s service) rejectionLabel(ctx context.Context, code string) string {
+	messageID := "rejection_reason." + code
+	label, err := i18n.FromContext(ctx).TryLocalize(messageID, map[string]interface{}{
+		"MaxAgeDays": s.claimMaxAgeDays(),
+	})
+	if err != nil || label == messageID {
+		return code
+	}
+	return label
+}
+
+func (s service) GetRejectionReasons(ctx context.Context) []dto.RejectionReason {
+	reasons := make([]dto.RejectionReason, 0, len(constants.RejectionReasons))
+	for _, code := range constants.RejectionReasons {
+		reasons = append(reasons, dto.RejectionReason{Code: code, Label: s.rejectionLabel(ctx, code)})
+	}
+	return reasons
+}
+
+func (s service) GetRejectionReport(ctx context.Context, filter dto.RejectionReportFilter) ([]dto.RejectionReasonReport, error) {
+	to := filter.To
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+
+	from := filter.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+
+	if !from.Before(to) {
+		return nil, errors.New("invalid report period")
+	}
+
+	stats, err := s.repo.Mileage().GetRejectionStats(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every code of the catalogue is reported, the ones nobody used with zero counts
+	reports := make([]dto.RejectionReasonReport, 0, len(constants.RejectionReasons))
+	positions := make(map[string]int, len(constants.RejectionReasons))
+	for _, code := range constants.RejectionReasons {
+		positions[code] = len(reports)
+		reports = append(reports, dto.RejectionReasonReport{Code: code, Label: s.rejectionLabel(ctx, code)})
+	}
+	for _, stat := range stats {
+		idx, ok := positions[stat.Code]
+		if !ok {
+			idx = len(reports)
+			reports = append(reports, dto.RejectionReasonReport{Code: stat.Code, Label: s.rejectionLabel(ctx, stat.Code)})
+		}
+		reports[idx].Segments = stat.Segments
+		reports[idx].Requests = stat.Requests
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Segments > reports[j].Segments
+	})
+
+	return reports, nil
+}
@@ -0,0 +1,311 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+	"github.com/viebiz/lit/iam"
+)
+
+func (s service) RepriceAccrualRequests(ctx context.Context, input dto.RepriceInput) (dto.RepricingResult, error) {
+	if len(input.RequestIDs) == 0 && input.AccrualRateChartID == "" && input.Carrier == "" && input.DepartureFrom == nil && input.DepartureTo == nil {
+		return dto.RepricingResult{}, errors.New("invalid repricing filter")
+	}
+
+	if input.DepartureFrom != nil && input.DepartureTo != nil && input.DepartureTo.Before(*input.DepartureFrom) {
+		return dto.RepricingResult{}, errors.New("invalid repricing filter")
+	}
+
+	if !input.DryRun && strings.TrimSpace(input.Reason) == "" {
+		return dto.RepricingResult{}, errors.New("repricing reason is required")
+	}
+
+	statuses := []string{constants.RequestStatusSubmitted, constants.RequestStatusUnderReview, constants.RequestStatusPendingSecondApproval}
+	if input.IncludeApproved {
+		statuses = append(statuses, constants.RequestStatusApproved)
+	}
+
+	requests, err := s.repo.Mileage().GetRepricingCandidates(ctx, statuses, input.RequestIDs, input.AccrualRateChartID,
+		strings.ToUpper(input.Carrier), input.DepartureFrom, input.DepartureTo)
+	if err != nil {
+		return dto.RepricingResult{}, err
+	}
+
+	result := dto.RepricingResult{
+		DryRun:   input.DryRun,
+		Requests: len(requests),
+		Items:    []dto.RepricingItem{},
+	}
+
+	if !input.DryRun {
+		runID, err := generator.RepricingRunID.Generate()
+		if err != nil {
+			return dto.RepricingResult{}, err
+		}
+		result.RunID = &runID
+	}
+
+	for _, req := range requests {
+		items, err := s.repriceRequest(ctx, req, input, result.RunID)
+		if err != nil {
+			// One request failing does not stop the run, running it again picks up what is left
+			result.Failed++
+			items = append(items, dto.RepricingItem{AccrualRequestID: req.ID, Error: err.Error()})
+		}
+
+		for _, item := range items {
+			if item.Error == "" {
+				result.Changed++
+			}
+		}
+		result.Items = append(result.Items, items...)
+	}
+
+	return result, nil
+}
+
+// repriceRequest prices the request with today's rules and, unless runID is nil, applies the segments that changed.
+// Once the changes are saved the items are returned even when the points account could not be moved yet
+func (s service) repriceRequest(ctx context.Context, req entity.AccrualRequest, input dto.RepriceInput, runID *uuid.UUID) ([]dto.RepricingItem, error) {
+	// A request a reviewer is working on is left to them, corrections an earlier run could not post go first
+	var promotions map[uuid.UUID][]entity.MilesLedger
+	if runID != nil {
+		if err := checkLock(ctx, req.LockedUntil, req.AssignedTo); err != nil {
+			return nil, err
+		}
+
+		ledgers, err := s.repo.Mileage().GetAccrualRequestLedgers(ctx, req.ID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.postLedgers(ctx, ledgers); err != nil {
+			return nil, err
+		}
+		promotions = promotionsBySegment(ledgers)
+	}
+
+	priced := req
+	priced.Segments = slices.Clone(req.Segments)
+
+	// A request the current rules can no longer price is reported and left as it is
+	if err := s.calculateMiles(ctx, &priced); err != nil {
+		return []dto.RepricingItem{{AccrualRequestID: req.ID, Error: err.Error()}}, nil
+	}
+
+	var (
+		items       []dto.RepricingItem
+		audits      []entity.AccrualRepricing
+		corrections []entity.MilesLedger
+	)
+	for idx, segment := range req.Segments {
+		repriced := priced.Segments[idx]
+
+		correction := segment.Status == constants.SegmentStatusApproved
+		if segment.Status == constants.SegmentStatusRejected || (correction && !input.IncludeApproved) {
+			continue
+		}
+
+		if segment.QualifyingMiles == repriced.QualifyingMiles &&
+			segment.BonusMiles == repriced.BonusMiles &&
+			segment.TierBonusMiles == repriced.TierBonusMiles {
+			continue
+		}
+
+		items = append(items, dto.RepricingItem{
+			AccrualRequestID:   req.ID,
+			SegmentID:          segment.ID,
+			SegmentStatus:      segment.Status,
+			FromCode:           segment.FromCode,
+			ToCode:             segment.ToCode,
+			OldQualifyingMiles: segment.QualifyingMiles,
+			NewQualifyingMiles: repriced.QualifyingMiles,
+			OldBonusMiles:      segment.BonusMiles,
+			NewBonusMiles:      repriced.BonusMiles,
+			OldTierBonusMiles:  segment.TierBonusMiles,
+			NewTierBonusMiles:  repriced.TierBonusMiles,
+			Correction:         correction,
+		})
+
+		if runID == nil {
+			continue
+		}
+
+		audit := entity.AccrualRepricing{
+			RunID:                 *runID,
+			AccrualRequestID:      req.ID,
+			SegmentID:             segment.ID,
+			SegmentStatus:         segment.Status,
+			OldAccrualRateChartID: segment.AccrualRateChartID,
+			NewAccrualRateChartID: repriced.AccrualRateChartID,
+			OldQualifyingMiles:    segment.QualifyingMiles,
+			NewQualifyingMiles:    repriced.QualifyingMiles,
+			OldBonusMiles:         segment.BonusMiles,
+			NewBonusMiles:         repriced.BonusMiles,
+			OldTierBonusMiles:     segment.TierBonusMiles,
+			NewTierBonusMiles:     repriced.TierBonusMiles,
+			Reason:                input.Reason,
+			RepricedBy:            iam.GetUserProfileFromContext(ctx).ID(),
+		}
+
+		if correction {
+			ledgers, err := correctionLedger(req, segment, repriced, *runID)
+			if err != nil {
+				return nil, err
+			}
+			corrections = append(corrections, ledgers...)
+			audit.CorrectionLedgerID = &ledgers[0].ID
+
+			promotionLedgers, err := s.promotionCorrections(ctx, priced, repriced, promotions[segment.ID], *runID)
+			if err != nil {
+				return nil, err
+			}
+			corrections = append(corrections, promotionLedgers...)
+		}
+
+		audits = append(audits, audit)
+		copyPricing(&req.Segments[idx], repriced)
+	}
+
+	if runID == nil || len(items) == 0 {
+		return items, nil
+	}
+
+	// The pricing, its audit records and the corrections are saved together, unless the request moved on meanwhile
+	loadedStatus := req.Status
+	summarise(&req)
+	saved, err := s.repo.Mileage().RepriceAccrualRequest(ctx, req, loadedStatus, iam.GetUserProfileFromContext(ctx).ID(), time.Now().UTC(), audits, corrections)
+	if err != nil {
+		return nil, err
+	}
+
+	if !saved {
+		return nil, errors.New("accrual request was changed by someone else")
+	}
+
+	if err := s.postLedgers(ctx, corrections); err != nil {
+		return items, err
+	}
+
+	return items, nil
+}
+
+// correctionLedger is the pending rows crediting or debiting the difference between what an approved segment earned and
+// what it should have earned. A difference that is up in one bucket and down in the other takes two rows, the first is
+// the one the audit record points at
+func correctionLedger(req entity.AccrualRequest, segment, repriced entity.AccrualRequestSegment, runID uuid.UUID) ([]entity.MilesLedger, error) {
+	// Generate ID here so the audit record can point at the correction
+	ledgerID, err := generator.MilesLedgerID.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	earningMonth := time.Date(segment.DepartureDate.Year(), segment.DepartureDate.Month(), 1, 0, 0, 0, 0, segment.DepartureDate.Location())
+	expiresAt := earningMonth.AddDate(0, 13, 0)
+	referenceID := fmt.Sprintf("%s:%s", segment.ID, runID)
+
+	return splitBySign(entity.MilesLedger{
+		ID:                   ledgerID,
+		CustomerID:           req.CustomerID,
+		QualifyingMilesDelta: repriced.QualifyingMiles - segment.QualifyingMiles,
+		BonusMilesDelta:      (repriced.BonusMiles + repriced.TierBonusMiles) - (segment.BonusMiles + segment.TierBonusMiles),
+		AccrualRequestID:     &req.ID,
+		SegmentID:            &segment.ID,
+		Kind:                 constants.LedgerKindCorrection,
+		EarningMonth:         earningMonth,
+		ExpiresAt:            &expiresAt,
+		Note:                 fmt.Sprintf("Re-pricing correction for flight %s %s-%s", segment.TicketID, segment.FromCode, segment.ToCode),
+		ReferenceID:          &referenceID,
+	}), nil
+}
+
+// promotionsBySegment groups the promotion rows of a request by segment, corrections of a promotion included
+func promotionsBySegment(ledgers []entity.MilesLedger) map[uuid.UUID][]entity.MilesLedger {
+	promotions := make(map[uuid.UUID][]entity.MilesLedger)
+	for _, ledger := range ledgers {
+		if ledger.SegmentID == nil || ledger.CampaignID == nil {
+			continue
+		}
+		promotions[*ledger.SegmentID] = append(promotions[*ledger.SegmentID], ledger)
+	}
+	return promotions
+}
+
+// promotionCorrections are the pending rows moving each promotion of a re-priced approved segment from what it
+// credited to what the segment earns at its new price. A promotion the segment no longer qualifies for is taken back
+func (s service) promotionCorrections(ctx context.Context, priced entity.AccrualRequest, repriced entity.AccrualRequestSegment, credited []entity.MilesLedger, runID uuid.UUID) ([]entity.MilesLedger, error) {
+	awards, err := s.campaign.Evaluate(ctx, priced, repriced)
+	if err != nil {
+		return nil, err
+	}
+
+	earned := make(map[uuid.UUID]miles.Miles, len(awards))
+	var campaignIDs []uuid.UUID
+	for _, award := range awards {
+		earned[award.CampaignID] += award.BonusMiles
+		campaignIDs = append(campaignIDs, award.CampaignID)
+	}
+
+	for _, ledger := range credited {
+		earned[*ledger.CampaignID] -= ledger.BonusMilesDelta
+		if !slices.Contains(campaignIDs, *ledger.CampaignID) {
+			campaignIDs = append(campaignIDs, *ledger.CampaignID)
+		}
+	}
+
+	earningMonth := time.Date(repriced.DepartureDate.Year(), repriced.DepartureDate.Month(), 1, 0, 0, 0, 0, repriced.DepartureDate.Location())
+	expiresAt := earningMonth.AddDate(0, 13, 0)
+
+	var ledgers []entity.MilesLedger
+	for _, campaignID := range campaignIDs {
+		if earned[campaignID] == 0 {
+			continue
+		}
+
+		referenceID := fmt.Sprintf("%s:%s:%s", repriced.ID, campaignID, runID)
+		ledgers = append(ledgers, entity.MilesLedger{
+			CustomerID:       priced.CustomerID,
+			BonusMilesDelta:  earned[campaignID],
+			AccrualRequestID: &priced.ID,
+			SegmentID:        &repriced.ID,
+			CampaignID:       &campaignID,
+			Kind:             constants.LedgerKindCorrection,
+			EarningMonth:     earningMonth,
+			ExpiresAt:        &expiresAt,
+			Note:             fmt.Sprintf("Re-pricing promotion correction for flight %s %s-%s", repriced.TicketID, repriced.FromCode, repriced.ToCode),
+			ReferenceID:      &referenceID,
+		})
+	}
+
+	return ledgers, nil
+}
+
+// copyPricing takes the priced amounts of src without touching the review state of dst
+func copyPricing(dst *entity.AccrualRequestSegment, src entity.AccrualRequestSegment) {
+	dst.DistanceMiles = src.DistanceMiles
+	dst.DistanceSource = src.DistanceSource
+	dst.EarningMode = src.EarningMode
+	dst.EligibleSpend = src.EligibleSpend
+	dst.ExchangeRate = src.ExchangeRate
+	dst.AccrualRateChartID = src.AccrualRateChartID
+	dst.QualifyingAccrualRate = src.QualifyingAccrualRate
+	dst.QualifyingMiles = src.QualifyingMiles
+	dst.BonusAccrualRate = src.BonusAccrualRate
+	dst.BonusMiles = src.BonusMiles
+	dst.TierBonusRate = src.TierBonusRate
+	dst.TierBonusMiles = src.TierBonusMiles
+}
+
+func (s service) GetRepricings(ctx context.Context, filter dto.RepricingFilter) ([]entity.AccrualRepricing, int64, error) {
+	return s.repo.Repricing().GetRepricings(ctx, filter.RunID, filter.AccrualRequestID, filter.Page, filter.Size)
+}
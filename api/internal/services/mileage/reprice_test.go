@@ -0,0 +1,160 @@
+package mileage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/campaign"
+	"github.com/google/uuid"
+)
+
+// fixedCampaigns awards the same promotions to every segment
+type fixedCampaigns struct {
+	campaign.Service
+	awards []dto.CampaignAward
+}
+
+func (c fixedCampaigns) Evaluate(_ context.Context, _ entity.AccrualRequest, _ entity.AccrualRequestSegment) ([]dto.CampaignAward, error) {
+	return c.awards, nil
+}
+
+func TestCorrectionLedger(t *testing.T) {
+	req := entity.AccrualRequest{ID: uuid.New(), CustomerID: uuid.New()}
+	runID := uuid.New()
+	segment := entity.AccrualRequestSegment{
+		ID:              uuid.New(),
+		DepartureDate:   time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		QualifyingMiles: 1000,
+		BonusMiles:      500,
+		TierBonusMiles:  100,
+	}
+
+	type expLedger struct {
+		reference       string
+		qualifyingMiles miles.Miles
+		bonusMiles      miles.Miles
+	}
+	reference := fmt.Sprintf("%s:%s", segment.ID, runID)
+
+	tcs := map[string]struct {
+		givenRepriced entity.AccrualRequestSegment
+		expLedgers    []expLedger
+	}{
+		"credit the difference": {
+			givenRepriced: entity.AccrualRequestSegment{QualifyingMiles: 1200, BonusMiles: 600, TierBonusMiles: 150},
+			expLedgers:    []expLedger{{reference: reference, qualifyingMiles: 200, bonusMiles: 150}},
+		},
+		"debit the difference": {
+			givenRepriced: entity.AccrualRequestSegment{QualifyingMiles: 800, BonusMiles: 400, TierBonusMiles: 100},
+			expLedgers:    []expLedger{{reference: reference, qualifyingMiles: -200, bonusMiles: -100}},
+		},
+		"tier uplift counts as bonus": {
+			givenRepriced: entity.AccrualRequestSegment{QualifyingMiles: 1000, BonusMiles: 500, TierBonusMiles: 50},
+			expLedgers:    []expLedger{{reference: reference, bonusMiles: -50}},
+		},
+		"mixed difference takes a credit and a debit row": {
+			givenRepriced: entity.AccrualRequestSegment{QualifyingMiles: 1100, BonusMiles: 400, TierBonusMiles: 100},
+			expLedgers: []expLedger{
+				{reference: reference + ":credit", qualifyingMiles: 100},
+				{reference: reference + ":debit", bonusMiles: -100},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ledgers, err := correctionLedger(req, segment, tc.givenRepriced, runID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(ledgers) != len(tc.expLedgers) {
+				t.Fatalf("expected %d rows, got %d", len(tc.expLedgers), len(ledgers))
+			}
+			if ledgers[0].ID == uuid.Nil {
+				t.Fatalf("expected the first row to have an ID for the audit record")
+			}
+			for idx, exp := range tc.expLedgers {
+				ledger := ledgers[idx]
+				if *ledger.ReferenceID != exp.reference || ledger.QualifyingMilesDelta != exp.qualifyingMiles || ledger.BonusMilesDelta != exp.bonusMiles {
+					t.Fatalf("expected row %d to be %+v, got %s %d %d", idx, exp, *ledger.ReferenceID, ledger.QualifyingMilesDelta, ledger.BonusMilesDelta)
+				}
+				if ledger.Kind != constants.LedgerKindCorrection || *ledger.SegmentID != segment.ID || *ledger.AccrualRequestID != req.ID || ledger.CustomerID != req.CustomerID {
+					t.Fatalf("expected row %d to correct the segment, got %+v", idx, ledger)
+				}
+				if !ledger.EarningMonth.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !ledger.ExpiresAt.Equal(time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC)) {
+					t.Fatalf("expected row %d to earn in the month of departure, got %s expiring %s", idx, ledger.EarningMonth, ledger.ExpiresAt)
+				}
+			}
+		})
+	}
+}
+
+func TestPromotionCorrections(t *testing.T) {
+	req := entity.AccrualRequest{ID: uuid.New(), CustomerID: uuid.New()}
+	runID := uuid.New()
+	repriced := entity.AccrualRequestSegment{ID: uuid.New(), DepartureDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)}
+	kept, dropped, added := uuid.New(), uuid.New(), uuid.New()
+	promotion := func(campaignID uuid.UUID, bonusMiles miles.Miles) entity.MilesLedger {
+		return entity.MilesLedger{CampaignID: &campaignID, BonusMilesDelta: bonusMiles}
+	}
+
+	tcs := map[string]struct {
+		givenAwards   []dto.CampaignAward
+		givenCredited []entity.MilesLedger
+		expDeltas     map[uuid.UUID]miles.Miles
+	}{
+		"unchanged promotion": {
+			givenAwards:   []dto.CampaignAward{{CampaignID: kept, BonusMiles: 300}},
+			givenCredited: []entity.MilesLedger{promotion(kept, 300)},
+			expDeltas:     map[uuid.UUID]miles.Miles{},
+		},
+		"promotion moves with the price": {
+			givenAwards:   []dto.CampaignAward{{CampaignID: kept, BonusMiles: 360}},
+			givenCredited: []entity.MilesLedger{promotion(kept, 300)},
+			expDeltas:     map[uuid.UUID]miles.Miles{kept: 60},
+		},
+		"earlier corrections are netted": {
+			givenAwards:   []dto.CampaignAward{{CampaignID: kept, BonusMiles: 360}},
+			givenCredited: []entity.MilesLedger{promotion(kept, 300), promotion(kept, 80)},
+			expDeltas:     map[uuid.UUID]miles.Miles{kept: -20},
+		},
+		"promotion no longer earned is taken back": {
+			givenCredited: []entity.MilesLedger{promotion(dropped, 300)},
+			expDeltas:     map[uuid.UUID]miles.Miles{dropped: -300},
+		},
+		"promotion newly earned is credited": {
+			givenAwards: []dto.CampaignAward{{CampaignID: added, BonusMiles: 500}},
+			expDeltas:   map[uuid.UUID]miles.Miles{added: 500},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			svc := service{campaign: fixedCampaigns{awards: tc.givenAwards}}
+
+			ledgers, err := svc.promotionCorrections(context.Background(), req, repriced, tc.givenCredited, runID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(ledgers) != len(tc.expDeltas) {
+				t.Fatalf("expected %d rows, got %d", len(tc.expDeltas), len(ledgers))
+			}
+			for _, ledger := range ledgers {
+				if ledger.BonusMilesDelta != tc.expDeltas[*ledger.CampaignID] || ledger.QualifyingMilesDelta != 0 {
+					t.Fatalf("expected %d bonus miles for campaign %s, got %d", tc.expDeltas[*ledger.CampaignID], *ledger.CampaignID, ledger.BonusMilesDelta)
+				}
+				if *ledger.ReferenceID != fmt.Sprintf("%s:%s:%s", repriced.ID, *ledger.CampaignID, runID) || ledger.Kind != constants.LedgerKindCorrection {
+					t.Fatalf("expected a correction keyed by segment, campaign and run, got %s %s", ledger.Kind, *ledger.ReferenceID)
+				}
+			}
+		})
+	}
+}
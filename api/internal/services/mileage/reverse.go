@@ -0,0 +1,147 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
+	"github.com/google/uuid"
+)
+
+// reversal is what is left to take back for one segment, or for the request itself when SegmentID is nil
+type reversal struct {
+	SegmentID       *uuid.UUID
+	QualifyingMiles miles.Miles
+	BonusMiles      miles.Miles
+	Source          entity.MilesLedger // Earliest row, the compensating row keeps its earning month and expiry
+}
+
+func (s service) ReverseAccrualRequest(ctx context.Context, reqID string, reason string, force bool) error {
+	if strings.TrimSpace(reason) == "" {
+		return errors.New("reversal reason is required")
+	}
+
+	existedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, reqID)
+	if err != nil {
+		return err
+	}
+
+	if existedRequest.ID == uuid.Nil {
+		return errors.New("accrual request does not exists")
+	}
+
+	// 1. Net out everything credited for the request, earlier corrections included.
+	// Rows still pending are posted first, so what is taken back was really credited
+	ledgers, err := s.repo.Mileage().GetAccrualRequestLedgers(ctx, existedRequest.ID.String())
+	if err != nil {
+		return err
+	}
+
+	if err := s.postLedgers(ctx, ledgers); err != nil {
+		return err
+	}
+
+	// A reversal that failed upstream is finished by reversing again
+	if existedRequest.Status == constants.RequestStatusReversed {
+		return nil
+	}
+
+	loadedStatus := existedRequest.Status
+	if err := transition(ctx, &existedRequest, constants.RequestStatusReversed, &reason); err != nil {
+		return err
+	}
+
+	reversals := netLedgers(ledgers)
+
+	var qualifyingMiles, bonusMiles miles.Miles
+	for _, r := range reversals {
+		qualifyingMiles += r.QualifyingMiles
+		bonusMiles += r.BonusMiles
+	}
+
+	// 2. Refuse to leave the member owing miles unless an admin insists
+	if !force && (qualifyingMiles > 0 || bonusMiles > 0) {
+		customer, err := s.repo.Customer().GetByID(ctx, existedRequest.CustomerID.String())
+		if err != nil {
+			return err
+		}
+
+		qualifyingBalance, bonusBalance, err := s.points.Balance(ctx, customer)
+		if err != nil {
+			return err
+		}
+
+		if qualifyingBalance < qualifyingMiles || bonusBalance < bonusMiles {
+			return errors.New("miles have already been spent")
+		}
+	}
+
+	// 3. Write the compensating ledger rows with the status, only one reversal of the request can get this far
+	corrections := make([]entity.MilesLedger, 0, len(reversals))
+	for _, r := range reversals {
+		referenceID := fmt.Sprintf("%s:reversal", existedRequest.ID)
+		if r.SegmentID != nil {
+			referenceID = fmt.Sprintf("%s:reversal", *r.SegmentID)
+		}
+
+		corrections = append(corrections, splitBySign(entity.MilesLedger{
+			CustomerID:           existedRequest.CustomerID,
+			QualifyingMilesDelta: -r.QualifyingMiles,
+			BonusMilesDelta:      -r.BonusMiles,
+			AccrualRequestID:     &existedRequest.ID,
+			SegmentID:            r.SegmentID,
+			Kind:                 constants.LedgerKindCorrection,
+			EarningMonth:         r.Source.EarningMonth,
+			ExpiresAt:            r.Source.ExpiresAt,
+			Note:                 fmt.Sprintf("Reversal of accrual request %s: %s", existedRequest.TicketID, reason),
+			ReferenceID:          &referenceID,
+		})...)
+	}
+
+	saved, err := s.repo.Mileage().UpdateAccrualRequest(ctx, existedRequest, loadedStatus, "", time.Now().UTC(), corrections...)
+	if err != nil {
+		return err
+	}
+
+	if !saved {
+		return errors.New("accrual request was changed by someone else")
+	}
+
+	// 4. Take the miles back, rows the points account refused stay pending for the next attempt
+	return s.postLedgers(ctx, corrections)
+}
+
+// netLedgers sums the ledger rows per segment and drops the segments with nothing left to take back
+func netLedgers(ledgers []entity.MilesLedger) []reversal {
+	var reversals []reversal
+	index := make(map[uuid.UUID]int)
+	for _, ledger := range ledgers {
+		key := uuid.Nil
+		if ledger.SegmentID != nil {
+			key = *ledger.SegmentID
+		}
+
+		idx, ok := index[key]
+		if !ok {
+			idx = len(reversals)
+			index[key] = idx
+			reversals = append(reversals, reversal{SegmentID: ledger.SegmentID, Source: ledger})
+		}
+
+		reversals[idx].QualifyingMiles += ledger.QualifyingMilesDelta
+		reversals[idx].BonusMiles += ledger.BonusMilesDelta
+	}
+
+	result := reversals[:0]
+	for _, r := range reversals {
+		if r.QualifyingMiles != 0 || r.BonusMiles != 0 {
+			result = append(result, r)
+		}
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/viebiz/lit/iam"
+)
+
+const (
+	defaultReviewLockMinutes = 30
+	defaultReviewSLAHours    = 48
+)
+
+func (s service) reviewSLA() time.Duration {
+	if s.cfg.ReviewSLAHours <= 0 {
+		return defaultReviewSLAHours * time.Hour
+	}
+	return time.Duration(s.cfg.ReviewSLAHours) * time.Hour
+}
+
+func (s service) GetReviewQueue(ctx context.Context, filter dto.ReviewQueueFilter) ([]dto.ReviewQueueItem, int64, error) {
+	userProfile := iam.GetUserProfileFromContext(ctx)
+	now := time.Now().UTC()
+
+	requests, total, err := s.repo.Mileage().GetReviewQueue(ctx, userProfile.ID(), filter.Mine, now, filter.Page, filter.Size)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]dto.ReviewQueueItem, 0, len(requests))
+	for _, req := range requests {
+		s.localizeRejections(ctx, &req)
+		dueAt := req.CreatedAt.Add(s.reviewSLA())
+		items = append(items, dto.ReviewQueueItem{
+			AccrualRequest: req,
+			WaitingHours:   now.Sub(req.CreatedAt).Hours(),
+			SLADueAt:       dueAt,
+			SLABreached:    now.After(dueAt),
+		})
+	}
+
+	return items, total, nil
+}
+
+func (s service) LockAccrualRequest(ctx context.Context, reqID string) (dto.ReviewLock, error) {
+	existedRequest, err := s.getReviewableRequest(ctx, reqID)
+	if err != nil {
+		return dto.ReviewLock{}, err
+	}
+
+	lockMinutes := s.cfg.ReviewLockMinutes
+	if lockMinutes <= 0 {
+		lockMinutes = defaultReviewLockMinutes
+	}
+
+	userID := iam.GetUserProfileFromContext(ctx).ID()
+	now := time.Now().UTC()
+	until := now.Add(time.Duration(lockMinutes) * time.Minute)
+
+	// Another reviewer may have claimed it since it was loaded
+	locked, err := s.repo.Mileage().LockAccrualRequest(ctx, existedRequest.ID.String(), userID, now, until)
+	if err != nil {
+		return dto.ReviewLock{}, err
+	}
+
+	if !locked {
+		return dto.ReviewLock{}, errors.New("accrual request is locked by another reviewer")
+	}
+
+	return dto.ReviewLock{
+		AccrualRequestID: existedRequest.ID,
+		AssignedTo:       userID,
+		LockedUntil:      until,
+	}, nil
+}
+
+func (s service) UnlockAccrualRequest(ctx context.Context, reqID string) error {
+	userID := iam.GetUserProfileFromContext(ctx).ID()
+
+	unlocked, err := s.repo.Mileage().UnlockAccrualRequest(ctx, reqID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !unlocked {
+		return errors.New("accrual request is not locked by you")
+	}
+
+	return nil
+}
+
+func (s service) GetReviewSLAReport(ctx context.Context, filter dto.ReviewSLAFilter) ([]dto.ReviewerSLAReport, error) {
+	now := time.Now().UTC()
+	to := filter.To
+	if to.IsZero() {
+		to = now
+	}
+
+	from := filter.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+
+	if !from.Before(to) {
+		return nil, errors.New("invalid report period")
+	}
+
+	stats, err := s.repo.Mileage().GetReviewerStats(ctx, int(s.reviewSLA().Hours()), from, to, now)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]dto.ReviewerSLAReport, 0, len(stats))
+	for _, stat := range stats {
+		report = append(report, dto.ReviewerSLAReport{
+			ReviewerID:     stat.ReviewerID,
+			Reviewed:       stat.Reviewed,
+			Breached:       stat.Breached,
+			AvgReviewHours: stat.AvgReviewHours,
+			Open:           stat.Open,
+			OpenBreached:   stat.OpenBreached,
+		})
+	}
+
+	return report, nil
+}
+
+// checkLock refuses a decision on a request another reviewer has claimed
+func checkLock(ctx context.Context, lockedUntil *time.Time, assignedTo *string) error {
+	if lockedUntil == nil || !lockedUntil.After(time.Now().UTC()) {
+		return nil
+	}
+
+	if assignedTo != nil && *assignedTo == iam.GetUserProfileFromContext(ctx).ID() {
+		return nil
+	}
+
+	return errors.New("accrual request is locked by another reviewer")
+}
@@ -4,14 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/erwin-lovecraft/aegismiles/internal/config"
 	"github.com/erwin-lovecraft/aegismiles/internal/constants"
 	"github.com/erwin-lovecraft/aegismiles/internal/entity"
 	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/generator"
 	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/campaign"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/risk"
 	"github.com/google/uuid"
 	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/monitoring"
 )
 
 type Service interface {
@@ -19,11 +25,94 @@ type Service interface {
 
 	GetAccrualRequests(ctx context.Context, filter dto.AccrualRequestFilter) ([]entity.AccrualRequest, int64, error)
 
+	// GetMyAccrualRequest returns one of the signed in member's requests with its history
+	GetMyAccrualRequest(ctx context.Context, reqID string) (dto.AccrualRequestDetail, error)
+
+	// GetAccrualRequest returns a request with its history, reviewers, ledger rows and deposit outcome
+	GetAccrualRequest(ctx context.Context, reqID string) (dto.AccrualRequestDetail, error)
+
 	SubmitAccrualRequest(ctx context.Context, request dto.AccrualRequestInput) error
 
+	// SubmitAccrualRequestOnBehalf files a claim for a customer, a late claim reason lifts the maximum claim age
+	SubmitAccrualRequestOnBehalf(ctx context.Context, request dto.AdminAccrualRequestInput) error
+
+	// CancelAccrualRequest withdraws one of the signed in member's requests before a reviewer has decided on it
+	CancelAccrualRequest(ctx context.Context, reqID string, reason *string) error
+
+	// VerifyBoardingPass records that the signed in reviewer checked the boarding pass, the request is then auto approved if the policy allows
+	VerifyBoardingPass(ctx context.Context, reqID string) error
+
+	// RetryDeposits posts the ledger rows of the request the points account has not moved for yet
+	RetryDeposits(ctx context.Context, reqID string) error
+
+	// RequestAccrualInfo sends the request back to the member with the reviewer's message
+	RequestAccrualInfo(ctx context.Context, reqID string, message string) error
+
+	// ReverseAccrualRequest takes back the miles credited for an approved request, force lets the balance go negative
+	ReverseAccrualRequest(ctx context.Context, reqID string, reason string, force bool) error
+
+	// ResubmitAccrualRequest applies the member's answer to a request for information and queues the request for review again
+	ResubmitAccrualRequest(ctx context.Context, input dto.ResubmitAccrualRequestInput) error
+
+	// GetMyComments lists the comments on one of the signed in member's requests, internal comments are left out
+	GetMyComments(ctx context.Context, reqID string) ([]entity.AccrualRequestComment, error)
+
+	// AddMyComment posts the signed in member's comment and notifies the reviewers
+	AddMyComment(ctx context.Context, input dto.CommentInput) (entity.AccrualRequestComment, error)
+
+	GetComments(ctx context.Context, reqID string) ([]entity.AccrualRequestComment, error)
+
+	// AddComment posts a reviewer's comment, the member is notified unless it is internal
+	AddComment(ctx context.Context, input dto.AdminCommentInput) (entity.AccrualRequestComment, error)
+
 	ApproveAccrualRequest(ctx context.Context, reqID string) error
 
-	RejectAccrualRequest(ctx context.Context, reqID string, rejectedReason string) error
+	RejectAccrualRequest(ctx context.Context, reqID string, rejection dto.Rejection) error
+
+	ApproveAccrualSegment(ctx context.Context, reqID string, segmentID string) error
+
+	RejectAccrualSegment(ctx context.Context, reqID string, segmentID string, rejection dto.Rejection) error
+
+	// GetRejectionReasons lists the reason codes a reviewer can reject with, labelled in the caller's language
+	GetRejectionReasons(ctx context.Context) []dto.RejectionReason
+
+	// GetRejectionReport counts the rejected segments and requests per reason code
+	GetRejectionReport(ctx context.Context, filter dto.RejectionReportFilter) ([]dto.RejectionReasonReport, error)
+
+	// BatchReviewAccrualRequests approves or rejects every request on its own, a failed item does not undo the others
+	BatchReviewAccrualRequests(ctx context.Context, input dto.BatchReviewInput) []dto.BatchReviewResult
+
+	// QuoteAccrual prices an itinerary for the signed in member without saving anything
+	QuoteAccrual(ctx context.Context, input dto.AccrualQuoteInput) (dto.AccrualQuote, error)
+
+	// QuotePublicAccrual prices an itinerary for an anonymous visitor, using the tier given in the input
+	QuotePublicAccrual(ctx context.Context, input dto.AccrualQuoteInput) (dto.AccrualQuote, error)
+
+	// RepriceAccrualRequests prices the selected requests again, a dry run only reports what would change
+	RepriceAccrualRequests(ctx context.Context, input dto.RepriceInput) (dto.RepricingResult, error)
+
+	GetRepricings(ctx context.Context, filter dto.RepricingFilter) ([]entity.AccrualRepricing, int64, error)
+
+	// GetReviewQueue lists the open requests for the signed in reviewer with their SLA timers
+	GetReviewQueue(ctx context.Context, filter dto.ReviewQueueFilter) ([]dto.ReviewQueueItem, int64, error)
+
+	// LockAccrualRequest claims an open request for the signed in reviewer for a limited time
+	LockAccrualRequest(ctx context.Context, reqID string) (dto.ReviewLock, error)
+
+	UnlockAccrualRequest(ctx context.Context, reqID string) error
+
+	// GetReviewSLAReport counts per reviewer the decisions and open requests that missed the review SLA
+	GetReviewSLAReport(ctx context.Context, filter dto.ReviewSLAFilter) ([]dto.ReviewerSLAReport, error)
+
+	GetAutoApprovalPolicy(ctx context.Context) (entity.AutoApprovalPolicy, error)
+
+	UpdateAutoApprovalPolicy(ctx context.Context, input dto.AutoApprovalPolicyInput) (entity.AutoApprovalPolicy, error)
+
+	// DisableAutoApproval is the kill switch, every new claim waits for a reviewer until the policy is enabled again
+	DisableAutoApproval(ctx context.Context) error
+
+	// GetAutoApprovalReport counts the requests approved by the policy and how many of them were reversed since
+	GetAutoApprovalReport(ctx context.Context, filter dto.AutoApprovalReportFilter) (dto.AutoApprovalReport, error)
 
 	GetMyMileageLedgers(ctx context.Context, filter dto.MileageLedgerFilter) ([]entity.MilesLedger, int64, error)
 
@@ -31,12 +120,20 @@ type Service interface {
 }
 
 type service struct {
-	repo repository.Repository
+	cfg      config.AccrualConfig
+	repo     repository.Repository
+	points   pointsAccount
+	campaign campaign.Service
+	risk     risk.Service
 }
 
-func New(repo repository.Repository) Service {
+func New(cfg config.AccrualConfig, repo repository.Repository, campaignSvc campaign.Service, riskSvc risk.Service) Service {
 	return service{
-		repo: repo,
+		cfg:      cfg,
+		repo:     repo,
+		points:   localPoints{repo: repo},
+		campaign: campaignSvc,
+		risk:     riskSvc,
 	}
 }
 
@@ -53,111 +150,299 @@ func (s service) SubmitAccrualRequest(ctx context.Context, request dto.AccrualRe
 		return errors.New("user not found")
 	}
 
-	// 2. Check exists request exists
-	existedRequest, err := s.repo.Mileage().GetAccrualRequestByFilter(ctx, customer.ID.String(), request.TicketID, request.PNR)
+	return s.submit(ctx, customer, request, nil)
+}
+
+func (s service) SubmitAccrualRequestOnBehalf(ctx context.Context, request dto.AdminAccrualRequestInput) error {
+	customer, err := s.repo.Customer().GetByID(ctx, request.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	if customer.ID == uuid.Nil {
+		return errors.New("user not found")
+	}
+
+	var override *claimWindowOverride
+	if request.LateClaimReason != "" {
+		override = &claimWindowOverride{
+			Reason: request.LateClaimReason,
+			By:     iam.GetUserProfileFromContext(ctx).ID(),
+		}
+	}
+
+	return s.submit(ctx, customer, request.AccrualRequestInput, override)
+}
+
+// submit files the claim for the customer, override is only set when an admin accepts a late claim
+func (s service) submit(ctx context.Context, customer entity.Customer, request dto.AccrualRequestInput, override *claimWindowOverride) error {
+	// 2. Check the flights are within the claim window and none of the segments was claimed before
+	segments, err := toSegmentEntities(request.TicketID, segmentInputs(request))
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkClaimWindow(segments, time.Now().UTC(), override != nil); err != nil {
+		return err
+	}
+
+	for _, segment := range segments {
+		claimed, err := s.repo.Mileage().GetClaimedSegment(ctx, customer.ID.String(), segment)
+		if err != nil {
+			return err
+		}
+
+		if claimed.ID != uuid.Nil {
+			return errors.New("accrual request already exists")
+		}
+	}
+
+	memberTier, err := s.points.MemberTier(ctx, customer)
 	if err != nil {
 		return err
 	}
 
-	if existedRequest.ID != uuid.Nil {
-		return errors.New("accrual request already exists")
+	// 3. Mapping value, the ID is needed to auto approve the request once saved
+	id, err := generator.AccrualRequestID.Generate()
+	if err != nil {
+		return err
 	}
 
-	// 2. Mapping value
 	e := entity.AccrualRequest{
+		ID:                   id,
 		CustomerID:           customer.ID,
 		TicketID:             request.TicketID,
 		PNR:                  request.PNR,
-		Carrier:              request.Carrier,
-		BookingClass:         request.BookingClass,
-		FromCode:             request.FromCode,
-		ToCode:               request.ToCode,
-		DepartureDate:        request.DepartureDate,
 		TicketImageURL:       request.TicketImageURL,
 		BoardingPassImageURL: request.BoardingPassImageURL,
-		Status:               constants.RequestStatusInProgress,
+		MemberTier:           memberTier,
+		BaseFare:             request.BaseFare,
+		Surcharges:           request.Surcharges,
+		FareCurrency:         toCurrency(request.FareCurrency),
+		Segments:             segments,
+	}
+	if override != nil {
+		e.LateClaimReason = &override.Reason
+		e.LateClaimApprovedBy = &override.By
 	}
 
-	// 3. Calculate miles and information
+	// 4. Calculate miles and information
 	if err := s.calculateMiles(ctx, &e); err != nil {
 		return err
 	}
 
-	// 4. Save to database
+	if err := s.risk.Assess(ctx, &e); err != nil {
+		return err
+	}
+
+	var reason *string
+	if override != nil {
+		reason = &override.Reason
+	}
+	if err := transition(ctx, &e, constants.RequestStatusSubmitted, reason); err != nil {
+		return err
+	}
+
+	// 5. Save to database
 	if err := s.repo.Mileage().SaveAccrualRequest(ctx, e); err != nil {
 		return err
 	}
 
+	// 6. Routine claims matching the auto approval policy skip the review queue
+	s.autoApprove(ctx, e)
+
 	return nil
 }
 
-func (s service) calculateMiles(ctx context.Context, req *entity.AccrualRequest) error {
-	distances, err := s.repo.Mileage().GetTravelDistance(ctx, req.FromCode, req.ToCode)
+func (s service) CancelAccrualRequest(ctx context.Context, reqID string, reason *string) error {
+	existedRequest, err := s.getOwnRequest(ctx, reqID)
 	if err != nil {
 		return err
 	}
-	req.DistanceMiles = distances.Miles
 
-	accrualRate := constants.AccuralRates[req.BookingClass]
-	req.QualifyingAccrualRate = accrualRate.QualifyingMile()
-	req.QualifyingMiles = req.QualifyingAccrualRate * float64(distances.Miles)
+	// Miles already credited for a segment can only be taken back by a reversal
+	for _, segment := range existedRequest.Segments {
+		if segment.Status != constants.SegmentStatusPending {
+			return errors.New("invalid status")
+		}
+	}
+
+	loadedStatus := existedRequest.Status
+	if err := transition(ctx, &existedRequest, constants.RequestStatusCancelled, reason); err != nil {
+		return err
+	}
+
+	return s.updateRequest(ctx, existedRequest, loadedStatus, "")
+}
 
-	req.BonusAccrualRate = accrualRate.BonusMileAt(float64(distances.Miles))
-	req.BonusMiles = req.BonusAccrualRate * float64(distances.Miles)
+func segmentInputs(request dto.AccrualRequestInput) []dto.AccrualSegmentInput {
+	if len(request.Segments) > 0 {
+		return request.Segments
+	}
 
-	return nil
+	// Flat fields describe a single segment claim
+	return []dto.AccrualSegmentInput{{
+		TicketID:         request.TicketID,
+		CouponNumber:     request.CouponNumber,
+		Carrier:          request.Carrier,
+		OperatingCarrier: request.OperatingCarrier,
+		BookingClass:     request.BookingClass,
+		FromCode:         request.FromCode,
+		ToCode:           request.ToCode,
+		DepartureDate:    request.DepartureDate,
+	}}
+}
+
+func toSegmentEntities(ticketID string, inputs []dto.AccrualSegmentInput) ([]entity.AccrualRequestSegment, error) {
+	segments := make([]entity.AccrualRequestSegment, 0, len(inputs))
+	seen := make(map[string]bool, len(inputs))
+	for idx, input := range inputs {
+		segment := entity.AccrualRequestSegment{
+			SegmentNo:     idx + 1,
+			Status:        constants.SegmentStatusPending,
+			TicketID:      input.TicketID,
+			CouponNumber:  input.CouponNumber,
+			Carrier:       strings.ToUpper(input.Carrier),
+			BookingClass:  strings.ToUpper(input.BookingClass),
+			FromCode:      strings.ToUpper(input.FromCode),
+			ToCode:        strings.ToUpper(input.ToCode),
+			DepartureDate: input.DepartureDate,
+		}
+		if segment.TicketID == "" {
+			segment.TicketID = ticketID
+		}
+
+		if input.OperatingCarrier != "" {
+			operatingCarrier := strings.ToUpper(input.OperatingCarrier)
+			segment.OperatingCarrier = &operatingCarrier
+		}
+
+		// The same coupon cannot be claimed twice within one itinerary either
+		key := fmt.Sprintf("%s/%d/%s/%s/%s", segment.TicketID, segment.CouponNumber, segment.FromCode, segment.ToCode, segment.DepartureDate.Format(time.DateOnly))
+		if segment.CouponNumber > 0 {
+			key = fmt.Sprintf("%s/%d", segment.TicketID, segment.CouponNumber)
+		}
+		if seen[key] {
+			return nil, errors.New("invalid itinerary")
+		}
+		seen[key] = true
+
+		segments = append(segments, segment)
+	}
+
+	return segments, nil
 }
 
 func (s service) ApproveAccrualRequest(ctx context.Context, reqID string) error {
+	return s.approveRequest(ctx, reqID, approvalManual)
+}
+
+// approveRequest approves every pending segment, bulk and auto approvals leave high risk claims to a reviewer
+func (s service) approveRequest(ctx context.Context, reqID string, mode approvalMode) error {
 	// 1. Get existed accrual request
-	existedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, reqID)
+	existedRequest, err := s.getReviewableRequest(ctx, reqID)
 	if err != nil {
 		return err
 	}
 
-	if existedRequest.ID == uuid.Nil {
-		return errors.New("accrual request does not exists")
+	if mode != approvalManual && s.risk.IsHighRisk(existedRequest.RiskScore) {
+		return errors.New("high risk request must be approved individually")
+	}
+	existedRequest.AutoApproved = mode == approvalAuto
+
+	// 2. High value claims and manual overrides take a second, different admin
+	if existedRequest.Status == constants.RequestStatusPendingSecondApproval {
+		if err := secondApproval(ctx, &existedRequest); err != nil {
+			return err
+		}
+	} else if s.needsSecondApproval(existedRequest) {
+		return s.firstApproval(ctx, existedRequest)
 	}
 
-	if existedRequest.Status != constants.RequestStatusInProgress {
-		return errors.New("invalid status")
+	// 3. Approve every segment that is still pending as a unit
+	var pending []int
+	for idx, segment := range existedRequest.Segments {
+		if segment.Status == constants.SegmentStatusPending {
+			pending = append(pending, idx)
+		}
 	}
 
-	userProfile := iam.GetUserProfileFromContext(ctx)
-	userID := userProfile.ID()
+	return s.approveSegments(ctx, existedRequest, pending)
+}
 
-	// 2. Do approve logic and save data
-	existedRequest.Status = constants.RequestStatusApproved
-	existedRequest.ReviewerID = &userID
-	now := time.Now().UTC()
-	existedRequest.ReviewedAt = &now
+func (s service) ApproveAccrualSegment(ctx context.Context, reqID string, segmentID string) error {
+	existedRequest, err := s.getReviewableRequest(ctx, reqID)
+	if err != nil {
+		return err
+	}
 
-	if err := s.repo.Mileage().SaveAccrualRequest(ctx, existedRequest); err != nil {
+	// Splitting a claim that needs two admins would let each segment slip under the threshold
+	if existedRequest.Status == constants.RequestStatusPendingSecondApproval || s.needsSecondApproval(existedRequest) {
+		return errors.New("accrual request needs a second approval")
+	}
+
+	idx, err := findPendingSegment(existedRequest, segmentID)
+	if err != nil {
 		return err
 	}
 
-	// 3. Update related customer miles
-	if err := s.repo.Mileage().IncreaseCustomerMiles(ctx, existedRequest.CustomerID.String(), existedRequest.QualifyingMiles, existedRequest.BonusMiles); err != nil {
+	return s.approveSegments(ctx, existedRequest, []int{idx})
+}
+
+func (s service) approveSegments(ctx context.Context, req entity.AccrualRequest, indices []int) error {
+	// 1. Do approve logic
+	loadedStatus := req.Status
+	for _, idx := range indices {
+		req.Segments[idx].Status = constants.SegmentStatusApproved
+	}
+	if err := settle(ctx, &req, nil); err != nil {
 		return err
 	}
 
-	// 4. Update miles ledgers with new fields
-	earningMonth := time.Date(existedRequest.DepartureDate.Year(), existedRequest.DepartureDate.Month(), 1, 0, 0, 0, 0, existedRequest.DepartureDate.Location())
-	expiresAt := earningMonth.AddDate(0, 13, 0)
-
-	if err := s.repo.Mileage().SaveMileageLedger(ctx, entity.MilesLedger{
-		CustomerID:           existedRequest.CustomerID,
-		QualifyingMilesDelta: existedRequest.QualifyingMiles,
-		BonusMilesDelta:      existedRequest.BonusMiles,
-		AccrualRequestID:     &existedRequest.ID,
-		Kind:                 "accrual",
-		EarningMonth:         earningMonth,
-		ExpiresAt:            &expiresAt,
-		Note:                 fmt.Sprintf("Accrual for flight %s", existedRequest.TicketID),
-	}); err != nil {
+	// 2. Write the miles ledgers for every approved segment and the promotions it qualifies for
+	var ledgers []entity.MilesLedger
+	for _, idx := range indices {
+		segment := req.Segments[idx]
+		earningMonth := time.Date(segment.DepartureDate.Year(), segment.DepartureDate.Month(), 1, 0, 0, 0, 0, segment.DepartureDate.Location())
+		expiresAt := earningMonth.AddDate(0, 13, 0)
+		referenceID := segment.ID.String()
+
+		ledgers = append(ledgers, entity.MilesLedger{
+			CustomerID:           req.CustomerID,
+			QualifyingMilesDelta: segment.QualifyingMiles,
+			BonusMilesDelta:      segment.BonusMiles + segment.TierBonusMiles,
+			AccrualRequestID:     &req.ID,
+			SegmentID:            &segment.ID,
+			Kind:                 constants.LedgerKindAccrual,
+			EarningMonth:         earningMonth,
+			ExpiresAt:            &expiresAt,
+			Note:                 fmt.Sprintf("Accrual for flight %s %s-%s", segment.TicketID, segment.FromCode, segment.ToCode),
+			ReferenceID:          &referenceID,
+		})
+
+		promotions, err := s.promotionLedgers(ctx, req, segment, earningMonth, expiresAt)
+		if err != nil {
+			return err
+		}
+		ledgers = append(ledgers, promotions...)
+	}
+
+	// 3. Save the decision with its ledgers, a concurrent decision fails here before anything is credited
+	saved, err := s.repo.Mileage().UpdateAccrualRequest(ctx, req, loadedStatus, iam.GetUserProfileFromContext(ctx).ID(), time.Now().UTC(), ledgers...)
+	if err != nil {
 		return err
 	}
 
+	if !saved {
+		return errors.New("accrual request was changed by someone else")
+	}
+
+	// 4. Credit miles to the customer's points account. The approval stands either way, a row the points account
+	// refused shows as a failed deposit until it is retried
+	if err := s.postLedgers(ctx, ledgers); err != nil {
+		monitoring.FromContext(ctx).Errorf(err, "[approveSegments] failed to post miles of accrual request %s", req.ID)
+	}
+
 	// 5. Check and update membership tier with current month
 	//currentMonth := time.Now().UTC()
 	//if _, _, err := s.membershipSvc.CalculateAndUpdateMembershipTierWithEffectiveMonth(ctx, existedRequest.CustomerID.String(), currentMonth); err != nil {
@@ -167,34 +452,196 @@ func (s service) ApproveAccrualRequest(ctx context.Context, reqID string) error
 	return nil
 }
 
-func (s service) RejectAccrualRequest(ctx context.Context, reqID string, rejectedReason string) error {
+// promotionLedgers are the rows of the promotions a segment qualifies for
+func (s service) promotionLedgers(ctx context.Context, req entity.AccrualRequest, segment entity.AccrualRequestSegment, earningMonth time.Time, expiresAt time.Time) ([]entity.MilesLedger, error) {
+	awards, err := s.campaign.Evaluate(ctx, req, segment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each campaign gets its own ledger row so it can be reported on separately
+	ledgers := make([]entity.MilesLedger, 0, len(awards))
+	for _, award := range awards {
+		referenceID := fmt.Sprintf("%s:%s", segment.ID, award.CampaignID)
+		ledgers = append(ledgers, entity.MilesLedger{
+			CustomerID:       req.CustomerID,
+			BonusMilesDelta:  award.BonusMiles,
+			AccrualRequestID: &req.ID,
+			SegmentID:        &segment.ID,
+			CampaignID:       &award.CampaignID,
+			Kind:             constants.LedgerKindPromotion,
+			EarningMonth:     earningMonth,
+			ExpiresAt:        &expiresAt,
+			Note:             fmt.Sprintf("Promotion %s for flight %s %s-%s", award.CampaignName, segment.TicketID, segment.FromCode, segment.ToCode),
+			ReferenceID:      &referenceID,
+		})
+	}
+
+	return ledgers, nil
+}
+
+func (s service) RejectAccrualRequest(ctx context.Context, reqID string, rejection dto.Rejection) error {
+	existedRequest, err := s.getReviewableRequest(ctx, reqID)
+	if err != nil {
+		return err
+	}
+
+	loadedStatus := existedRequest.Status
+	reason := s.newRejectedReason(ctx, rejection)
+	for idx := range existedRequest.Segments {
+		if existedRequest.Segments[idx].Status == constants.SegmentStatusPending {
+			existedRequest.Segments[idx].Status = constants.SegmentStatusRejected
+			reason.applyToSegment(&existedRequest.Segments[idx])
+		}
+	}
+	reason.applyToRequest(&existedRequest)
+	if err := settle(ctx, &existedRequest, &reason.Message); err != nil {
+		return err
+	}
+
+	if err := s.updateRequest(ctx, existedRequest, loadedStatus, iam.GetUserProfileFromContext(ctx).ID()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s service) RejectAccrualSegment(ctx context.Context, reqID string, segmentID string, rejection dto.Rejection) error {
+	existedRequest, err := s.getReviewableRequest(ctx, reqID)
+	if err != nil {
+		return err
+	}
+
+	idx, err := findPendingSegment(existedRequest, segmentID)
+	if err != nil {
+		return err
+	}
+
+	loadedStatus := existedRequest.Status
+	reason := s.newRejectedReason(ctx, rejection)
+	existedRequest.Segments[idx].Status = constants.SegmentStatusRejected
+	reason.applyToSegment(&existedRequest.Segments[idx])
+	if err := settle(ctx, &existedRequest, &reason.Message); err != nil {
+		return err
+	}
+
+	// Keep the reason on the request once nothing of it was approved
+	if existedRequest.Status == constants.RequestStatusRejected {
+		reason.applyToRequest(&existedRequest)
+	}
+
+	if err := s.updateRequest(ctx, existedRequest, loadedStatus, iam.GetUserProfileFromContext(ctx).ID()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getOwnRequest loads a request of the signed in member
+func (s service) getOwnRequest(ctx context.Context, reqID string) (entity.AccrualRequest, error) {
+	userProfile := iam.GetUserProfileFromContext(ctx)
+
+	customer, err := s.repo.Customer().GetByUserID(ctx, userProfile.ID())
+	if err != nil {
+		return entity.AccrualRequest{}, err
+	}
+
+	if customer.ID == uuid.Nil {
+		return entity.AccrualRequest{}, errors.New("user not found")
+	}
+
 	existedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, reqID)
+	if err != nil {
+		return entity.AccrualRequest{}, err
+	}
+
+	// Requests of other customers are reported as missing rather than forbidden
+	if existedRequest.ID == uuid.Nil || existedRequest.CustomerID != customer.ID {
+		return entity.AccrualRequest{}, errors.New("accrual request does not exists")
+	}
+
+	return existedRequest, nil
+}
+
+// updateRequest saves a request loaded in loadedStatus, it fails when someone else decided on or locked the request in between
+func (s service) updateRequest(ctx context.Context, req entity.AccrualRequest, loadedStatus string, reviewerID string) error {
+	saved, err := s.repo.Mileage().UpdateAccrualRequest(ctx, req, loadedStatus, reviewerID, time.Now().UTC())
 	if err != nil {
 		return err
 	}
 
+	if !saved {
+		return errors.New("accrual request was changed by someone else")
+	}
+
+	return nil
+}
+
+func (s service) getReviewableRequest(ctx context.Context, reqID string) (entity.AccrualRequest, error) {
+	existedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, reqID)
+	if err != nil {
+		return entity.AccrualRequest{}, err
+	}
+
 	if existedRequest.ID == uuid.Nil {
-		return errors.New("accrual request does not exists")
+		return entity.AccrualRequest{}, errors.New("accrual request does not exists")
+	}
+
+	switch existedRequest.Status {
+	case constants.RequestStatusSubmitted, constants.RequestStatusUnderReview, constants.RequestStatusPendingSecondApproval:
+	default:
+		return entity.AccrualRequest{}, errors.New("invalid status")
 	}
 
-	if existedRequest.Status != constants.RequestStatusInProgress {
-		return errors.New("invalid status")
+	if err := checkLock(ctx, existedRequest.LockedUntil, existedRequest.AssignedTo); err != nil {
+		return entity.AccrualRequest{}, err
 	}
 
+	return existedRequest, nil
+}
+
+func findPendingSegment(req entity.AccrualRequest, segmentID string) (int, error) {
+	for idx, segment := range req.Segments {
+		if segment.ID.String() != segmentID {
+			continue
+		}
+
+		if segment.Status != constants.SegmentStatusPending {
+			return 0, errors.New("invalid status")
+		}
+		return idx, nil
+	}
+
+	return 0, errors.New("accrual request segment does not exists")
+}
+
+// settle records the reviewer and moves the request on, it is closed once every segment has been decided
+func settle(ctx context.Context, req *entity.AccrualRequest, reason *string) error {
 	userProfile := iam.GetUserProfileFromContext(ctx)
 	userID := userProfile.ID()
-
-	existedRequest.Status = constants.RequestStatusRejected
-	existedRequest.ReviewerID = &userID
 	now := time.Now().UTC()
-	existedRequest.ReviewedAt = &now
-	existedRequest.RejectedReason = &rejectedReason
+	req.ReviewerID = &userID
+	req.ReviewedAt = &now
+	summarise(req)
+
+	status := constants.RequestStatusRejected
+	for _, segment := range req.Segments {
+		if segment.Status == constants.SegmentStatusPending {
+			status = constants.RequestStatusUnderReview
+			break
+		}
+
+		if segment.Status == constants.SegmentStatusApproved {
+			status = constants.RequestStatusApproved
+		}
+	}
 
-	if err := s.repo.Mileage().SaveAccrualRequest(ctx, existedRequest); err != nil {
-		return err
+	// Deciding one more segment of a request under review does not change its status
+	if status == req.Status {
+		return nil
 	}
 
-	return nil
+	return transition(ctx, req, status, reason)
 }
 
 func (s service) GetMyAccrualRequests(ctx context.Context, filter dto.AccrualRequestFilter) ([]entity.AccrualRequest, int64, error) {
@@ -205,27 +652,50 @@ func (s service) GetMyAccrualRequests(ctx context.Context, filter dto.AccrualReq
 		return nil, 0, err
 	}
 
-	return s.repo.Mileage().GetAccrualRequests(
+	requests, total, err := s.repo.Mileage().GetAccrualRequests(
 		ctx,
 		filter.Keyword,
 		customer.ID.String(),
 		filter.Status,
 		filter.SubmittedDate,
+		0, // Members cannot filter by risk
 		filter.Page,
 		filter.Size,
 	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fraud signals are for reviewers only
+	for idx := range requests {
+		requests[idx].RiskScore = 0
+		requests[idx].RiskHits = nil
+		s.localizeRejections(ctx, &requests[idx])
+	}
+
+	return requests, total, nil
 }
 
 func (s service) GetAccrualRequests(ctx context.Context, filter dto.AccrualRequestFilter) ([]entity.AccrualRequest, int64, error) {
-	return s.repo.Mileage().GetAccrualRequests(
+	requests, total, err := s.repo.Mileage().GetAccrualRequests(
 		ctx,
 		filter.Keyword,
 		"", // Means not filter by customer_id
 		filter.Status,
 		filter.SubmittedDate,
+		filter.MinRiskScore,
 		filter.Page,
 		filter.Size,
 	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for idx := range requests {
+		s.localizeRejections(ctx, &requests[idx])
+	}
+
+	return requests, total, nil
 }
 
 func (s service) GetMyMileageLedgers(ctx context.Context, filter dto.MileageLedgerFilter) ([]entity.MilesLedger, int64, error) {
@@ -254,3 +724,12 @@ func (s service) GetMileageLedgers(ctx context.Context, filter dto.MileageLedger
 		filter.Size,
 	)
 }
+
+func toCurrency(code string) *string {
+	if code == "" {
+		return nil
+	}
+
+	currency := strings.ToUpper(code)
+	return &currency
+}
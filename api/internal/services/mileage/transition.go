@@ -0,0 +1,75 @@
+package mileage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/constants"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/viebiz/lit/iam"
+)
+
+// requestTransitions lists the statuses an accrual request may move to from each status, anything else is refused
+var requestTransitions = map[string][]string{
+	"": {constants.RequestStatusSubmitted},
+	constants.RequestStatusSubmitted: {
+		constants.RequestStatusUnderReview,
+		constants.RequestStatusNeedsInfo,
+		constants.RequestStatusPendingSecondApproval,
+		constants.RequestStatusApproved,
+		constants.RequestStatusRejected,
+		constants.RequestStatusCancelled,
+	},
+	constants.RequestStatusUnderReview: {
+		constants.RequestStatusNeedsInfo,
+		constants.RequestStatusPendingSecondApproval,
+		constants.RequestStatusApproved,
+		constants.RequestStatusRejected,
+	},
+	constants.RequestStatusPendingSecondApproval: {
+		constants.RequestStatusUnderReview,
+		constants.RequestStatusNeedsInfo,
+		constants.RequestStatusApproved,
+		constants.RequestStatusRejected,
+	},
+	constants.RequestStatusNeedsInfo: {
+		constants.RequestStatusSubmitted,
+		constants.RequestStatusRejected,
+		constants.RequestStatusCancelled,
+	},
+	constants.RequestStatusApproved: {constants.RequestStatusReversed},
+}
+
+func canTransition(from string, to string) bool {
+	for _, status := range requestTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transition moves the request to the given status and records who did it, the event is persisted with the request
+func transition(ctx context.Context, req *entity.AccrualRequest, to string, reason *string) error {
+	if !canTransition(req.Status, to) {
+		return errors.New("invalid status")
+	}
+
+	userProfile := iam.GetUserProfileFromContext(ctx)
+	req.Events = append(req.Events, entity.AccrualRequestEvent{
+		FromStatus: req.Status,
+		ToStatus:   to,
+		Actor:      userProfile.ID(),
+		Reason:     reason,
+		CreatedAt:  time.Now().UTC(),
+	})
+	req.Status = to
+
+	// A claim on the request only lasts while it waits for a decision
+	if to != constants.RequestStatusSubmitted && to != constants.RequestStatusUnderReview {
+		req.LockedUntil = nil
+	}
+
+	return nil
+}
@@ -4,100 +4,161 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/erwin-lovecraft/aegismiles/internal/config"
-	"github.com/erwin-lovecraft/aegismiles/internal/constants"
 	"github.com/erwin-lovecraft/aegismiles/internal/entity"
 	"github.com/erwin-lovecraft/aegismiles/internal/gateway/sessionm"
 	"github.com/erwin-lovecraft/aegismiles/internal/models/dto"
+	"github.com/erwin-lovecraft/aegismiles/internal/pkg/miles"
 	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/campaign"
+	"github.com/erwin-lovecraft/aegismiles/internal/services/risk"
 	"github.com/google/uuid"
-	"github.com/viebiz/lit/iam"
 )
 
-type serviceV2 struct {
-	service
+func NewV2(cfg config.SessionMConfig, accrualCfg config.AccrualConfig, sessionmGwy sessionm.Client, repo repository.Repository, campaignSvc campaign.Service, riskSvc risk.Service) Service {
+	return service{
+		cfg:  accrualCfg,
+		repo: repo,
+		points: sessionmPoints{
+			cfg:         cfg,
+			sessionmSvc: sessionmGwy,
+		},
+		campaign: campaignSvc,
+		risk:     riskSvc,
+	}
+}
 
+// sessionmPoints keeps the balance on SessionM
+type sessionmPoints struct {
 	cfg         config.SessionMConfig
 	sessionmSvc sessionm.Client
 }
 
-func NewV2(cfg config.SessionMConfig, sessionmGwy sessionm.Client, repo repository.Repository) Service {
-	return serviceV2{
-		cfg:         cfg,
-		sessionmSvc: sessionmGwy,
-		service: service{
-			repo: repo,
-		},
-	}
+func (p sessionmPoints) Provider() string {
+	return pointsProviderSessionM
 }
 
-func (s serviceV2) ApproveAccrualRequest(ctx context.Context, reqID string) error {
-	// 1. Get existed accrual request
-	existedRequest, err := s.repo.Mileage().GetAccrualRequest(ctx, reqID)
+func (p sessionmPoints) MemberTier(ctx context.Context, customer entity.Customer) (string, error) {
+	profile, err := p.sessionmSvc.GetUser(ctx, customer.Auth0UserID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	if existedRequest.ID == uuid.Nil {
-		return errors.New("accrual request does not exists")
+	return sessionm.MemberTier(profile, p.cfg.TierSystemID), nil
+}
+
+func (p sessionmPoints) Balance(ctx context.Context, customer entity.Customer) (miles.Miles, miles.Miles, error) {
+	profile, err := p.sessionmSvc.GetUser(ctx, customer.Auth0UserID)
+	if err != nil {
+		return 0, 0, err
 	}
 
-	if existedRequest.Status != constants.RequestStatusInProgress {
-		return errors.New("invalid status")
+	// A fraction of a mile cannot be spent
+	var qualifyingMiles, bonusMiles miles.Miles
+	for _, detail := range profile.TierDetails.PointAccountBalances.Details {
+		switch {
+		case detail.PointAccountID == p.cfg.PointAccountID:
+			qualifyingMiles = miles.RoundDown.Round(detail.AvailableBalance)
+		case p.cfg.BonusAccountID != "" && detail.PointAccountID == p.cfg.BonusAccountID:
+			bonusMiles = miles.RoundDown.Round(detail.AvailableBalance)
+		}
 	}
 
-	userProfile := iam.GetUserProfileFromContext(ctx)
-	userID := userProfile.ID()
+	return qualifyingMiles, bonusMiles, nil
+}
 
-	// 2. Do approve logic and save data
-	existedRequest.Status = constants.RequestStatusApproved
-	existedRequest.ReviewerID = &userID
-	now := time.Now().UTC()
-	existedRequest.ReviewedAt = &now
+// Post runs a single deposit or withdrawal under the row's reference, rows are written with one sign by splitBySign
+func (p sessionmPoints) Post(ctx context.Context, ledger entity.MilesLedger) error {
+	credits := ledger.QualifyingMilesDelta > 0 || ledger.BonusMilesDelta > 0
+	debits := ledger.QualifyingMilesDelta < 0 || ledger.BonusMilesDelta < 0
+	switch {
+	case credits && debits:
+		return errors.New("ledger row both credits and debits miles")
+	case credits:
+		return p.Deposit(ctx, ledger.CustomerID, *ledger.ReferenceID, ledger.QualifyingMilesDelta, ledger.BonusMilesDelta)
+	case debits:
+		return p.Withdraw(ctx, ledger.CustomerID, *ledger.ReferenceID, -ledger.QualifyingMilesDelta, -ledger.BonusMilesDelta)
+	}
 
-	if err := s.repo.Mileage().SaveAccrualRequest(ctx, existedRequest); err != nil {
+	return nil
+}
+
+func (p sessionmPoints) Deposit(ctx context.Context, customerID uuid.UUID, referenceID string, qualifyingMiles miles.Miles, bonusMiles miles.Miles) error {
+	details, err := p.details(referenceID, qualifyingMiles, bonusMiles)
+	if err != nil {
 		return err
 	}
 
-	// 3. Deposit points to sessionm
-	if _, err := s.sessionmSvc.DepositPoints(ctx, dto.SessionMDepositPointsRequest{
-		RetailerID:             s.cfg.RetailerID,
-		UserID:                 existedRequest.CustomerID.String(),
+	if len(details) == 0 {
+		return nil
+	}
+
+	_, err = p.sessionmSvc.DepositPoints(ctx, dto.SessionMDepositPointsRequest{
+		RetailerID:             p.cfg.RetailerID,
+		UserID:                 customerID.String(),
 		AllowPartialSuccess:    false,
 		DisableEventPublishing: false,
 		Culture:                "en-US",
-		DepositDetails: []dto.SessionMDepositDetail{
-			{
-				PointSourceID:  s.cfg.PointSourceID,
-				PointAccountID: s.cfg.PointAccountID,
-				Amount:         existedRequest.QualifyingMiles,
-				ReferenceID:    existedRequest.ID.String(),
-				ReferenceType:  "accrual_request",
-			},
-			// TODO: Bonus miles
-		},
-	}); err != nil {
-		return err
+		DepositDetails:         details,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", errPointsUpstream, err)
 	}
+	return nil
+}
 
-	// 4. Update miles ledgers with new fields
-	earningMonth := time.Date(existedRequest.DepartureDate.Year(), existedRequest.DepartureDate.Month(), 1, 0, 0, 0, 0, existedRequest.DepartureDate.Location())
-	expiresAt := earningMonth.AddDate(0, 13, 0)
-
-	if err := s.repo.Mileage().SaveMileageLedger(ctx, entity.MilesLedger{
-		CustomerID:           existedRequest.CustomerID,
-		QualifyingMilesDelta: existedRequest.QualifyingMiles,
-		BonusMilesDelta:      existedRequest.BonusMiles,
-		AccrualRequestID:     &existedRequest.ID,
-		Kind:                 "accrual",
-		EarningMonth:         earningMonth,
-		ExpiresAt:            &expiresAt,
-		Note:                 fmt.Sprintf("Accrual for flight %s", existedRequest.TicketID),
-	}); err != nil {
+func (p sessionmPoints) Withdraw(ctx context.Context, customerID uuid.UUID, referenceID string, qualifyingMiles miles.Miles, bonusMiles miles.Miles) error {
+	details, err := p.details(referenceID, qualifyingMiles, bonusMiles)
+	if err != nil {
 		return err
 	}
 
+	if len(details) == 0 {
+		return nil
+	}
+
+	_, err = p.sessionmSvc.WithdrawPoints(ctx, dto.SessionMWithdrawPointsRequest{
+		RetailerID:             p.cfg.RetailerID,
+		UserID:                 customerID.String(),
+		AllowPartialSuccess:    false,
+		DisableEventPublishing: false,
+		Culture:                "en-US",
+		WithdrawDetails:        details,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", errPointsUpstream, err)
+	}
 	return nil
 }
+
+func (p sessionmPoints) details(referenceID string, qualifyingMiles miles.Miles, bonusMiles miles.Miles) ([]dto.SessionMDepositDetail, error) {
+	var details []dto.SessionMDepositDetail
+	if qualifyingMiles > 0 {
+		details = append(details, dto.SessionMDepositDetail{
+			PointSourceID:  p.cfg.PointSourceID,
+			PointAccountID: p.cfg.PointAccountID,
+			Amount:         qualifyingMiles.Float64(),
+			ReferenceID:    referenceID,
+			ReferenceType:  "accrual_request",
+		})
+	}
+
+	// Bonus miles do not count towards tier points, so they go to a separate account.
+	// Without one they would be lost while the ledger still records them
+	if bonusMiles > 0 {
+		if p.cfg.BonusAccountID == "" {
+			return nil, errors.New("bonus point account is not configured")
+		}
+
+		details = append(details, dto.SessionMDepositDetail{
+			PointSourceID:  p.cfg.PointSourceID,
+			PointAccountID: p.cfg.BonusAccountID,
+			Amount:         bonusMiles.Float64(),
+			ReferenceID:    referenceID,
+			ReferenceType:  "accrual_request_bonus",
+		})
+	}
+
+	return details, nil
+}
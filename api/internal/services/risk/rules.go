@@ -0,0 +1,97 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+)
+
+// sharedTicket flags a ticket another member has claimed as well
+type sharedTicket struct {
+	repo repository.Repository
+}
+
+func (r sharedTicket) Evaluate(ctx context.Context, req entity.AccrualRequest) (*entity.RiskHit, error) {
+	claimants, err := r.repo.Mileage().CountOtherClaimants(ctx, req.CustomerID, ticketIDs(req))
+	if err != nil {
+		return nil, err
+	}
+
+	if claimants == 0 {
+		return nil, nil
+	}
+
+	// A ticket belongs to one passenger, so this alone keeps the claim out of bulk and auto approval
+	return &entity.RiskHit{
+		Rule:   "shared_ticket",
+		Score:  defaultHighRiskScore,
+		Detail: fmt.Sprintf("ticket is claimed by %d other member(s)", claimants),
+	}, nil
+}
+
+// sameDayFlights flags flights on other tickets departing the same day, a member cannot be on both
+type sameDayFlights struct {
+	repo repository.Repository
+}
+
+func (r sameDayFlights) Evaluate(ctx context.Context, req entity.AccrualRequest) (*entity.RiskHit, error) {
+	dates := make([]time.Time, 0, len(req.Segments))
+	for _, segment := range req.Segments {
+		dates = append(dates, segment.DepartureDate)
+	}
+
+	flights, err := r.repo.Mileage().CountSameDayFlights(ctx, req.CustomerID, req.ID, ticketIDs(req), dates)
+	if err != nil {
+		return nil, err
+	}
+
+	if flights == 0 {
+		return nil, nil
+	}
+
+	return &entity.RiskHit{
+		Rule:   "same_day_flights",
+		Score:  30,
+		Detail: fmt.Sprintf("%d flight(s) on other tickets depart the same day", flights),
+	}, nil
+}
+
+// claimVelocity flags more claims in a week than a member plausibly flies
+type claimVelocity struct {
+	repo            repository.Repository
+	maxWeeklyClaims int
+}
+
+func (r claimVelocity) Evaluate(ctx context.Context, req entity.AccrualRequest) (*entity.RiskHit, error) {
+	since := time.Now().UTC().AddDate(0, 0, -7)
+	claims, err := r.repo.Mileage().CountClaimsSince(ctx, req.CustomerID, req.ID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	// The claim being assessed counts too
+	if claims+1 <= int64(r.maxWeeklyClaims) {
+		return nil, nil
+	}
+
+	return &entity.RiskHit{
+		Rule:   "claim_velocity",
+		Score:  25,
+		Detail: fmt.Sprintf("%d claims in the last 7 days", claims+1),
+	}, nil
+}
+
+func ticketIDs(req entity.AccrualRequest) []string {
+	seen := make(map[string]bool, len(req.Segments))
+	ids := make([]string, 0, len(req.Segments))
+	for _, segment := range req.Segments {
+		if !seen[segment.TicketID] {
+			seen[segment.TicketID] = true
+			ids = append(ids, segment.TicketID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,80 @@
+package risk
+
+import (
+	"context"
+
+	"github.com/erwin-lovecraft/aegismiles/internal/config"
+	"github.com/erwin-lovecraft/aegismiles/internal/entity"
+	"github.com/erwin-lovecraft/aegismiles/internal/repository"
+)
+
+const (
+	defaultHighRiskScore   = 60
+	defaultMaxWeeklyClaims = 5
+	maxScore               = 100
+)
+
+// Rule looks at a claim before it is saved and returns a hit when the claim looks suspicious
+type Rule interface {
+	Evaluate(ctx context.Context, req entity.AccrualRequest) (*entity.RiskHit, error)
+}
+
+type Service interface {
+	// Assess runs every rule and keeps the triggered ones and the risk score on the request
+	Assess(ctx context.Context, req *entity.AccrualRequest) error
+
+	// IsHighRisk tells whether a claim with the score must be approved by hand, one at a time
+	IsHighRisk(score int) bool
+}
+
+type service struct {
+	cfg   config.RiskConfig
+	rules []Rule
+}
+
+// New creates the rules engine with the built-in rules, extra rules are evaluated after them
+func New(cfg config.RiskConfig, repo repository.Repository, extra ...Rule) Service {
+	maxWeeklyClaims := cfg.MaxWeeklyClaims
+	if maxWeeklyClaims <= 0 {
+		maxWeeklyClaims = defaultMaxWeeklyClaims
+	}
+
+	rules := []Rule{
+		sharedTicket{repo: repo},
+		sameDayFlights{repo: repo},
+		claimVelocity{repo: repo, maxWeeklyClaims: maxWeeklyClaims},
+	}
+
+	return service{
+		cfg:   cfg,
+		rules: append(rules, extra...),
+	}
+}
+
+func (s service) Assess(ctx context.Context, req *entity.AccrualRequest) error {
+	hits := make([]entity.RiskHit, 0)
+	score := 0
+	for _, rule := range s.rules {
+		hit, err := rule.Evaluate(ctx, *req)
+		if err != nil {
+			return err
+		}
+
+		if hit != nil {
+			hits = append(hits, *hit)
+			score += hit.Score
+		}
+	}
+
+	req.RiskHits = hits
+	req.RiskScore = min(score, maxScore)
+	return nil
+}
+
+func (s service) IsHighRisk(score int) bool {
+	threshold := s.cfg.HighRiskScore
+	if threshold <= 0 {
+		threshold = defaultHighRiskScore
+	}
+	return score >= threshold
+}
@@ -16,14 +16,14 @@ interface AccrualRequestDetailDialogProps {
 
 const getStatusInfo = (data: MileageAccrualRequest) => {
   switch (data.status) {
-    case 'pending':
+    case 'submitted':
       return {
         icon: <Clock className="w-5 h-5" />,
         badge: <Badge variant="secondary" className="bg-amber-50 text-amber-700 border-amber-200">Pending</Badge>,
         color: 'text-amber-600',
         label: "Submitted to Admin"
       };
-    case 'inprogress':
+    case 'under_review':
       return {
         icon: <AlertCircle className="w-5 h-5" />,
         badge: <Badge variant="secondary" className="bg-blue-50 text-blue-700 border-blue-200">In Progress</Badge>,
@@ -13,14 +13,14 @@ export type MileageRequestPreviewProps = {
 
 const getStatusInfo = (data: MileageAccrualRequest) => {
   switch (data.status) {
-    case 'pending':
+    case 'submitted':
       return {
         icon: <Clock className="w-4 h-4" />,
         badge: <Badge variant="secondary" className="bg-amber-50 text-amber-700 border-amber-200">Pending</Badge>,
         color: 'text-amber-600',
         label: "Submitted to Admin"
       };
-    case 'inprogress':
+    case 'under_review':
       return {
         icon: <AlertCircle className="w-4 h-4" />,
         badge: <Badge variant="secondary" className="bg-blue-50 text-blue-700 border-blue-200">In Progress</Badge>,
@@ -65,7 +65,7 @@ export default function MileageRequestPreview(props: MileageRequestPreviewProps)
       className="group relative bg-white rounded-xl border border-gray-100 shadow-sm hover:shadow-md transition-all duration-200 ease-in-out overflow-hidden w-full max-w-full"
     >
       {/* Status indicator bar */}
-      <div className={`absolute top-0 left-0 right-0 h-1 ${data.status === 'approved' ? 'bg-green-500' : data.status === 'rejected' ? 'bg-red-500' : data.status === 'inprogress' ? 'bg-blue-500' : 'bg-amber-500'}`} />
+      <div className={`absolute top-0 left-0 right-0 h-1 ${data.status === 'approved' ? 'bg-green-500' : data.status === 'rejected' ? 'bg-red-500' : data.status === 'under_review' ? 'bg-blue-500' : 'bg-amber-500'}`} />
 
       <div className="p-4 sm:p-6">
         {/* Header */}
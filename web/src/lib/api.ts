@@ -1,6 +1,7 @@
 import axios, { type AxiosInstance, type InternalAxiosRequestConfig } from 'axios';
 import { config as envConfig } from '@/config/env';
 import { useAuth0 } from '@auth0/auth0-react';
+import i18n from '@/i18n';
 
 // Create axios instance
 const apiClient: AxiosInstance = axios.create({
@@ -66,6 +67,8 @@ export const useApiClient = () => {
           const token = await getAccessTokenSilently();
           if (config.headers) {
             config.headers.Authorization = `Bearer ${token}`;
+            // Reasons and other server messages come back in the selected language
+            config.headers['X-Language-key'] = i18n.resolvedLanguage ?? 'en';
           }
         } catch (error) {
           console.error('Failed to get access token:', error);